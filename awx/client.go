@@ -0,0 +1,140 @@
+package awx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	awx "github.com/denouche/goawx/client"
+)
+
+// apiClients keeps, for every configured provider instance, a client able to
+// reach the AWX endpoints that goawx does not wrap.
+var apiClients sync.Map
+
+// apiClient performs raw calls against the AWX API with the same base URL,
+// credentials and http client as the goawx services of a provider instance.
+type apiClient struct {
+	requester *awx.Requester
+
+	mu     sync.Mutex
+	server serverInfo
+}
+
+// apiError is returned by apiClient when AWX answers with a non 2xx status.
+type apiError struct {
+	StatusCode int
+	Endpoint   string
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s responded with %d: %s", e.Endpoint, e.StatusCode, e.Body)
+}
+
+func registerAPIClient(c *awx.AWX, requester *awx.Requester) *apiClient {
+	api := &apiClient{requester: requester}
+	apiClients.Store(c, api)
+	return api
+}
+
+func apiClientFor(m interface{}) *apiClient {
+	c, ok := apiClients.Load(m)
+	if !ok {
+		return nil
+	}
+	return c.(*apiClient)
+}
+
+// uiURL returns the link to a page of the AWX web interface.
+func (c *apiClient) uiURL(format string, a ...interface{}) string {
+	return strings.TrimSuffix(c.requester.Base, "/") + "/#" + fmt.Sprintf(format, a...)
+}
+
+func (c *apiClient) getJSON(endpoint string, result interface{}, params map[string]string) error {
+	return c.do(http.MethodGet, endpoint, nil, result, params)
+}
+
+// optionsJSON returns the metadata AWX describes an endpoint with, such as
+// the defaults of the settings.
+func (c *apiClient) optionsJSON(endpoint string, result interface{}) error {
+	return c.do(http.MethodOptions, endpoint, nil, result, nil)
+}
+
+func (c *apiClient) postJSON(endpoint string, data interface{}, result interface{}) error {
+	return c.do(http.MethodPost, endpoint, data, result, nil)
+}
+
+func (c *apiClient) patchJSON(endpoint string, data interface{}, result interface{}) error {
+	return c.do(http.MethodPatch, endpoint, data, result, nil)
+}
+
+func (c *apiClient) delete(endpoint string) error {
+	return c.do(http.MethodDelete, endpoint, nil, nil, nil)
+}
+
+// getText returns the raw body of endpoint, used for job stdout.
+func (c *apiClient) getText(endpoint string, params map[string]string) (string, error) {
+	var body string
+	resp, err := c.requester.Get(endpoint, &body, params)
+	if err != nil {
+		return "", requestError(endpoint, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &apiError{StatusCode: resp.StatusCode, Endpoint: endpoint, Body: body}
+	}
+	return body, nil
+}
+
+func (c *apiClient) do(method, endpoint string, data interface{}, result interface{}, params map[string]string) error {
+	var payload *bytes.Reader
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	} else {
+		payload = bytes.NewReader(nil)
+	}
+
+	ar := awx.NewAPIRequest(method, endpoint, payload)
+	ar.SetHeader("Content-Type", "application/json")
+
+	var body string
+	resp, err := c.requester.Do(ar, &body, params)
+	if err != nil {
+		return requestError(endpoint, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &apiError{StatusCode: resp.StatusCode, Endpoint: endpoint, Body: body}
+	}
+	if result == nil || body == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(body), result)
+}
+
+// requestError returns the error of a failed request, unwrapping the apiError
+// of a 400 response from the error of the http client.
+func requestError(endpoint string, err error) error {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return &apiError{StatusCode: apiErr.StatusCode, Endpoint: endpoint, Body: apiErr.Body}
+	}
+	return err
+}
+
+// badRequestBody returns the raw body of the 400 response err comes from, as
+// kept by badRequestTransport.
+func badRequestBody(err error) (string, bool) {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		return apiErr.Body, true
+	}
+	return "", false
+}
@@ -12,3 +12,21 @@ func AtoipOr(s string, defaultValue *int) *int {
 	}
 	return &n
 }
+
+// toIntSlice converts a schema list of integers
+func toIntSlice(l []interface{}) []int {
+	result := make([]int, 0, len(l))
+	for _, v := range l {
+		result = append(result, v.(int))
+	}
+	return result
+}
+
+// toStringSlice converts a schema list of strings
+func toStringSlice(l []interface{}) []string {
+	result := make([]string, 0, len(l))
+	for _, v := range l {
+		result = append(result, v.(string))
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+/*
+Use this data source to read the version and license of the AWX server.
+
+Example Usage
+
+```hcl
+data "awx_config" "current" {}
+```
+
+*/
+package awx
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of AWX, or of Automation Controller on AAP",
+			},
+			"license_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "License type, open for AWX",
+			},
+			"install_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Unique identifier of the installation",
+			},
+		},
+	}
+}
+
+func dataSourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	api := apiClientFor(m)
+	if api == nil {
+		return buildDiagnosticsMessage(
+			"Get: Fail to fetch AWX config",
+			"The provider is not configured",
+		)
+	}
+	server := api.detectedServer()
+	if server.Version == "" {
+		if err := api.loadServerInfo(); err != nil {
+			return buildDiagnosticsMessage(
+				"Get: Fail to fetch AWX config",
+				"Fail to read the AWX version and license got: %s",
+				err.Error(),
+			)
+		}
+		server = api.detectedServer()
+	}
+
+	d.Set("version", server.Version)
+	d.Set("license_type", server.LicenseType)
+	d.Set("install_uuid", server.InstallUUID)
+	d.SetId(server.InstallUUID)
+	return diags
+}
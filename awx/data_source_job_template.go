@@ -37,6 +37,12 @@ func dataSourceJobTemplate() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"organization": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"name"},
+				Description:  "Name of the organization of the job template, for names used in several organizations",
+			},
 		},
 	}
 }
@@ -53,6 +59,18 @@ func dataSourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m in
 		params["id"] = strconv.Itoa(groupID.(int))
 	}
 
+	if organization, ok := d.GetOk("organization"); ok {
+		id, err := jobTemplateKey.resolve(apiClientFor(m), organization.(string)+naturalKeySeparator+params["name"])
+		if err != nil {
+			return buildDiagnosticsMessage(
+				"Get: Fail to fetch job template",
+				"Fail to find the job template got: %s",
+				err.Error(),
+			)
+		}
+		params = map[string]string{"id": strconv.Itoa(id)}
+	}
+
 	if len(params) == 0 {
 		return buildDiagnosticsMessage(
 			"Get: Missing Parameters",
@@ -35,6 +35,12 @@ func dataSourceWorkflowJobTemplate() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"organization": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"name"},
+				Description:  "Name of the organization of the workflow job template, for names used in several organizations",
+			},
 		},
 	}
 }
@@ -51,6 +57,18 @@ func dataSourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceDa
 		params["id"] = strconv.Itoa(groupID.(int))
 	}
 
+	if organization, ok := d.GetOk("organization"); ok {
+		id, err := workflowJobTemplateKey.resolve(apiClientFor(m), organization.(string)+naturalKeySeparator+params["name"])
+		if err != nil {
+			return buildDiagnosticsMessage(
+				"Get: Fail to fetch workflow job template",
+				"Fail to find the workflow job template got: %s",
+				err.Error(),
+			)
+		}
+		params = map[string]string{"id": strconv.Itoa(id)}
+	}
+
 	if len(params) == 0 {
 		return buildDiagnosticsMessage(
 			"Get: Missing Parameters",
@@ -0,0 +1,233 @@
+package awx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultAPIPathPrefix is where AWX and AAP before 2.5 serve the controller API.
+	defaultAPIPathPrefix = "/api/"
+	gatewayLoginEndpoint = "/api/gateway/v1/login/"
+)
+
+// normalizeAPIPathPrefix returns prefix with a single leading and trailing slash.
+func normalizeAPIPathPrefix(prefix string) string {
+	return "/" + strings.Trim(prefix, "/") + "/"
+}
+
+// discoverAPIPathPrefix asks the API root of hostname where the controller API
+// lives: AWX describes its own versions there, while the AAP 2.5 gateway lists
+// the APIs of the platform components, the controller included.
+func discoverAPIPathPrefix(ctx context.Context, client *http.Client, hostname string) (string, error) {
+	endpoint := strings.TrimSuffix(hostname, "/") + defaultAPIPathPrefix
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s responded with %d", endpoint, resp.StatusCode)
+	}
+
+	var root struct {
+		APIs map[string]string `json:"apis"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return "", fmt.Errorf("unable to decode %s: %s", endpoint, err)
+	}
+	if controller := root.APIs["controller"]; controller != "" {
+		return normalizeAPIPathPrefix(controller), nil
+	}
+	return defaultAPIPathPrefix, nil
+}
+
+// apiPathTransport moves the requests goawx sends to /api/v2/ under the
+// controller API prefix, such as /api/controller/v2/ behind the AAP gateway.
+type apiPathTransport struct {
+	next http.RoundTripper
+	// root is the path of the API root below the hostname, such as /api/.
+	root   string
+	prefix string
+}
+
+func newAPIPathTransport(next http.RoundTripper, hostname, prefix string) (*apiPathTransport, error) {
+	base, err := url.Parse(hostname)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hostname: %s", err)
+	}
+	basePath := strings.TrimSuffix(base.Path, "/")
+	return &apiPathTransport{
+		next:   next,
+		root:   basePath + defaultAPIPathPrefix,
+		prefix: basePath + prefix,
+	}, nil
+}
+
+func (t *apiPathTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasPrefix(req.URL.Path, t.root+"v2/") {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.URL.Path = t.prefix + strings.TrimPrefix(req.URL.Path, t.root)
+	req.URL.RawPath = ""
+	return t.next.RoundTrip(req)
+}
+
+// gatewaySessionTransport authenticates the requests with a session opened on
+// the AAP gateway login endpoint, instead of the basic auth header goawx sets.
+// The session is opened on first use, and again when the gateway answers 401.
+type gatewaySessionTransport struct {
+	next     http.RoundTripper
+	hostname string
+	username string
+	password string
+	// passwordSource, when set, is read again each time the session is renewed.
+	passwordSource *secretSource
+
+	mu      sync.Mutex
+	cookies map[string]*http.Cookie
+}
+
+func (t *gatewaySessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	cookies, err := t.session(req.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(t.authenticate(req, cookies))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	tflog.Debug(req.Context(), "AAP gateway session expired, logging in again")
+	cookies, err = t.session(req.Context(), cookies)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	retry := t.authenticate(req, cookies)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	return t.next.RoundTrip(retry)
+}
+
+// session returns the cookies of the current session, logging in when there
+// is none yet or when the current one is the expired session.
+func (t *gatewaySessionTransport) session(ctx context.Context, expired map[string]*http.Cookie) (map[string]*http.Cookie, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cookies != nil && (expired == nil || !sameSession(t.cookies, expired)) {
+		return t.cookies, nil
+	}
+
+	cookies, err := t.login(ctx, expired != nil)
+	if err != nil {
+		return nil, err
+	}
+	t.cookies = cookies
+	return cookies, nil
+}
+
+// login opens a session with the credentials of the provider. The gateway
+// requires the CSRF token cookie it sets on GET to be echoed in the POST.
+func (t *gatewaySessionTransport) login(ctx context.Context, renew bool) (map[string]*http.Cookie, error) {
+	endpoint := strings.TrimSuffix(t.hostname, "/") + gatewayLoginEndpoint
+	cookies := map[string]*http.Cookie{}
+
+	password := t.password
+	if t.passwordSource != nil {
+		var err error
+		if password, err = t.passwordSource.get(ctx); err == nil && renew {
+			password, err = t.passwordSource.renew(ctx, password)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.loginStep(req, cookies); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{"username": {t.username}, "password": {password}}
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = t.authenticate(req, cookies)
+	if err := t.loginStep(req, cookies); err != nil {
+		return nil, err
+	}
+	return cookies, nil
+}
+
+func (t *gatewaySessionTransport) loginStep(req *http.Request, cookies map[string]*http.Cookie) error {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("AAP gateway login on %s responded with %d, check the username and password", req.URL.Path, resp.StatusCode)
+	}
+	for _, cookie := range resp.Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return nil
+}
+
+// authenticate returns a copy of req carrying the session cookies, and the
+// CSRF headers the gateway expects on unsafe methods.
+func (t *gatewaySessionTransport) authenticate(req *http.Request, cookies map[string]*http.Cookie) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Del("Authorization")
+	for _, cookie := range cookies {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+	default:
+		if csrf, ok := cookies["csrftoken"]; ok {
+			req.Header.Set("X-CSRFToken", csrf.Value)
+		}
+		req.Header.Set("Referer", strings.TrimSuffix(t.hostname, "/")+"/")
+	}
+	return req
+}
+
+func sameSession(a, b map[string]*http.Cookie) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, cookie := range a {
+		if other, ok := b[name]; !ok || other.Value != cookie.Value {
+			return false
+		}
+	}
+	return true
+}
@@ -3,10 +3,16 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"gopkg.in/yaml.v2"
@@ -20,6 +26,30 @@ const (
 	diagElementHostTitle            = "Host"
 )
 
+// rawConfigReader is implemented by both schema.ResourceData and schema.ResourceDiff.
+type rawConfigReader interface {
+	GetRawConfig() cty.Value
+}
+
+// isConfigured reports whether an attribute is set in the configuration, even to its zero value.
+func isConfigured(d rawConfigReader, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(key) {
+		return false
+	}
+	return !config.GetAttr(key).IsNull()
+}
+
+// isKnown reports whether the configured value of an attribute is known, it
+// is not when it depends on resources yet to be created.
+func isKnown(d rawConfigReader, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(key) {
+		return false
+	}
+	return config.GetAttr(key).IsWhollyKnown()
+}
+
 func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
@@ -29,7 +59,7 @@ func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m in
 		return diags
 	}
 	_, err := awxService.DeleteJobTemplate(id)
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			"JobTemplate",
 			fmt.Sprintf(
@@ -41,21 +71,127 @@ func resourceJobTemplateDelete(ctx context.Context, d *schema.ResourceData, m in
 	d.SetId("")
 	return nil
 }
-func buildDiagCreateFail(tfMethode string, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+func buildDiagCreateFail(tfMethode string, err error, d rawConfigReader) diag.Diagnostics {
+	return buildDiagAPIFail(
+		fmt.Sprintf("Unable to create %s", tfMethode),
 		fmt.Sprintf("Unable to create %s", tfMethode),
-		"Unable to create %s got %s",
-		tfMethode, err.Error(),
+		err, d,
 	)
 }
-func buildDiagUpdateFail(tfMethode string, id int, err error) diag.Diagnostics {
-	return buildDiagnosticsMessage(
+func buildDiagUpdateFail(tfMethode string, id int, err error, d rawConfigReader) diag.Diagnostics {
+	return buildDiagAPIFail(
 		fmt.Sprintf("Unable to update %s", tfMethode),
-		"Unable to update %s with id %d: got %s",
-		tfMethode, id, err.Error(),
+		fmt.Sprintf("Unable to update %s with id %d", tfMethode, id),
+		err, d,
 	)
 }
 
+// buildDiagAPIFail builds the diagnostics of a failed API call. Each field error
+// of an AWX validation response gets its own diagnostic, pointing at the
+// matching attribute of d when there is one.
+func buildDiagAPIFail(diagSummary, diagDetails string, err error, d rawConfigReader) diag.Diagnostics {
+	fieldErrors := awxValidationErrors(err)
+	if len(fieldErrors) == 0 {
+		if body, ok := badRequestBody(err); ok {
+			return buildDiagnosticsMessage(diagSummary, "%s: AWX rejected the request: %s", diagDetails, body)
+		}
+		return buildDiagnosticsMessage(diagSummary, "%s: got %s", diagDetails, err.Error())
+	}
+
+	fields := make([]string, 0, len(fieldErrors))
+	for field := range fieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var diags diag.Diagnostics
+	for _, field := range fields {
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  diagSummary,
+			Detail:   fmt.Sprintf("%s: %s: %s", diagDetails, field, fieldErrors[field]),
+		}
+		attribute := matchingAttribute(d, field)
+		if field == "" || (attribute != "" && !strings.ContainsAny(field, ".[")) {
+			// The field is obvious from the attribute, or there is none.
+			diagnostic.Detail = fmt.Sprintf("%s: %s", diagDetails, fieldErrors[field])
+		}
+		if attribute != "" {
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diags = append(diags, diagnostic)
+	}
+	return diags
+}
+
+// awxValidationErrors returns the messages of an AWX 400 response by field,
+// nested fields being named by their path such as inputs.password. Messages
+// not bound to a field, such as a list or a string body, are under "".
+func awxValidationErrors(err error) map[string]string {
+	body, ok := badRequestBody(err)
+	if !ok {
+		return nil
+	}
+	var document interface{}
+	if json.Unmarshal([]byte(body), &document) != nil {
+		return nil
+	}
+	fieldErrors := map[string]string{}
+	collectValidationErrors(fieldErrors, "", document)
+	return fieldErrors
+}
+
+func collectValidationErrors(fieldErrors map[string]string, field string, document interface{}) {
+	switch value := document.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			path := key
+			if field != "" {
+				path = field + "." + key
+			}
+			collectValidationErrors(fieldErrors, path, nested)
+		}
+	case []interface{}:
+		var messages []string
+		for i, item := range value {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				collectValidationErrors(fieldErrors, fmt.Sprintf("%s[%d]", field, i), item)
+			default:
+				messages = append(messages, fmt.Sprint(item))
+			}
+		}
+		if len(messages) > 0 {
+			fieldErrors[field] = strings.Join(messages, "\n")
+		}
+	case nil:
+	default:
+		fieldErrors[field] = fmt.Sprint(value)
+	}
+}
+
+// matchingAttribute returns the attribute of d an AWX field is set from, such
+// as inventory_id for inventory or credential_ids for credentials, or an empty
+// string when there is none.
+func matchingAttribute(d rawConfigReader, field string) string {
+	if d == nil {
+		return ""
+	}
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.Type().IsObjectType() {
+		return ""
+	}
+	if i := strings.IndexAny(field, ".["); i >= 0 {
+		field = field[:i]
+	}
+	for _, attribute := range []string{field, field + "_id", field + "_ids", strings.TrimSuffix(field, "s") + "_ids"} {
+		if config.Type().HasAttribute(attribute) {
+			return attribute
+		}
+	}
+	return ""
+}
+
 func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		fmt.Sprintf("Unable to fetch %s", tfMethode),
@@ -64,6 +200,51 @@ func buildDiagNotFoundFail(tfMethode string, id int, err error) diag.Diagnostics
 	)
 }
 
+// isNotFound reports whether err is AWX answering 404, to a goawx service or
+// to apiClient.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	// goawx services fail with the status formatted by awx.CheckResponse.
+	return err != nil && strings.Contains(err.Error(), "responsed with 404")
+}
+
+// buildDiagReadFail returns the diagnostics of a Read unable to fetch its
+// object. An object deleted outside of Terraform is removed from the state
+// with a warning instead, so that the next plan creates it again.
+func buildDiagReadFail(tfMethode string, id int, err error, d *schema.ResourceData) diag.Diagnostics {
+	if !isNotFound(err) {
+		return buildDiagNotFoundFail(tfMethode, id, err)
+	}
+	return removeFromState(d, fmt.Sprintf("%s with id %d", tfMethode, id))
+}
+
+// buildDiagFetchFail returns the diagnostics of an Update unable to fetch its
+// object. The state can't be dropped during an update, so an object deleted
+// outside of Terraform is reported as such, the next refresh removing it.
+func buildDiagFetchFail(tfMethode string, id int, err error) diag.Diagnostics {
+	if !isNotFound(err) {
+		return buildDiagNotFoundFail(tfMethode, id, err)
+	}
+	return buildDiagnosticsMessage(
+		fmt.Sprintf("%s not found", tfMethode),
+		"%s with id %d no longer exists in AWX, refresh the state so that the next plan creates it again",
+		tfMethode, id,
+	)
+}
+
+func removeFromState(d *schema.ResourceData, element string) diag.Diagnostics {
+	log.Printf("%s not found, removing it from the state", element)
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s not found", element),
+		Detail:   fmt.Sprintf("%s no longer exists in AWX, it was removed from the state.", element),
+	}}
+}
+
 func buildDiagDeleteFail(tfMethode, details string) diag.Diagnostics {
 	return buildDiagnosticsMessage(
 		buildDiagDeleteFailSummary(tfMethode),
@@ -77,6 +258,20 @@ func buildDiagDeleteFailDetails(tfMethode, detailsString string) string {
 	return fmt.Sprintf("Fail to delete %s, %s", tfMethode, detailsString)
 }
 
+func buildDiagJobFailed(tfElement string, id int, status, url, explanation, stdoutTail string) diag.Diagnostics {
+	details := fmt.Sprintf("%s with ID %d reached the %s state, see %s", tfElement, id, status, url)
+	if explanation != "" {
+		details = fmt.Sprintf("%s\nExplanation: %s", details, explanation)
+	}
+	if stdoutTail != "" {
+		details = fmt.Sprintf("%s\nLast lines of output:\n%s", details, stdoutTail)
+	}
+	return buildDiagnosticsMessage(
+		fmt.Sprintf("%s execution %s", tfElement, status),
+		"%s", details,
+	)
+}
+
 func convertStateIDToNummeric(tfElement string, d *schema.ResourceData) (int, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	id, err := strconv.Atoi(d.Id())
@@ -90,6 +285,70 @@ func convertStateIDToNummeric(tfElement string, d *schema.ResourceData) (int, di
 	return id, diags
 }
 
+// importStateCompositeID returns the importer of a resource linking two AWX
+// objects, imported as <parent>:<child> where each side is an ID or a natural
+// key. Both IDs are stored in their attributes, childKey being empty when the
+// child ID is only the resource ID, and the resource ID is the child ID as
+// set by Create.
+func importStateCompositeID(parentKey string, parent naturalKey, childKey string, child naturalKey) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			parts := strings.Split(d.Id(), ":")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("unexpected import ID %q, expected <%s>:<%s>", d.Id(), parentKey, childKeyOrID(childKey))
+			}
+			parentID, err := parent.resolve(apiClientFor(m), parts[0])
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve %s: %s", parentKey, err)
+			}
+			childID, err := child.resolve(apiClientFor(m), parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve %s: %s", childKeyOrID(childKey), err)
+			}
+
+			d.Set(parentKey, parentID)
+			if childKey != "" {
+				d.Set(childKey, childID)
+			}
+			d.SetId(strconv.Itoa(childID))
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
+func childKeyOrID(childKey string) string {
+	if childKey == "" {
+		return "id"
+	}
+	return childKey
+}
+
+// readAssociation returns the Read of a resource linking the object in
+// childKey to the object in parentKey, listed at endpoint. The resource is
+// removed from the state once the link is gone.
+func readAssociation(tfElement, endpoint, parentKey, childKey string) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		parentID := d.Get(parentKey).(int)
+		childID := d.Get(childKey).(int)
+
+		var list struct {
+			Count int `json:"count"`
+		}
+		err := apiClientFor(m).getJSON(fmt.Sprintf(endpoint, parentID), &list, map[string]string{"id": strconv.Itoa(childID)})
+		if err != nil && !isNotFound(err) {
+			return buildDiagnosticsMessage(
+				fmt.Sprintf("Unable to fetch %s", tfElement),
+				"Unable to check %s between %s %d and %s %d, got %s",
+				tfElement, parentKey, parentID, childKey, childID, err.Error(),
+			)
+		}
+		if err != nil || list.Count == 0 {
+			return removeFromState(d, fmt.Sprintf("%s between %s %d and %s %d", tfElement, parentKey, parentID, childKey, childID))
+		}
+		return nil
+	}
+}
+
 func buildDiagnosticsMessage(diagSummary, diagDetails string, detailsVars ...interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	diags = append(diags, diag.Diagnostic{
@@ -106,7 +365,7 @@ func CredentialsServiceDeleteByID(ctx context.Context, d *schema.ResourceData, m
 	id, _ := strconv.Atoi(d.Id())
 	client := m.(*awx.AWX)
 	err := client.CredentialsService.DeleteCredentialsByID(id, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to delete existing credentials",
@@ -122,7 +381,7 @@ func CredentialTypeServiceDeleteByID(ctx context.Context, d *schema.ResourceData
 	id, _ := strconv.Atoi(d.Id())
 	client := m.(*awx.AWX)
 	err := client.CredentialTypeService.DeleteCredentialTypeByID(id, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to delete existing credential type",
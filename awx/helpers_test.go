@@ -0,0 +1,173 @@
+package awx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// testConfig is a rawConfigReader of a fixed configuration.
+type testConfig cty.Value
+
+func (c testConfig) GetRawConfig() cty.Value {
+	return cty.Value(c)
+}
+
+func TestAWXValidationErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected map[string]string
+	}{
+		{
+			name:     "not a bad request",
+			err:      &apiError{StatusCode: http.StatusNotFound, Body: `{"detail":"Not found."}`},
+			expected: nil,
+		},
+		{
+			name:     "not json",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: "Bad Request"},
+			expected: nil,
+		},
+		{
+			name:     "fields",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `{"name":["This field may not be blank."],"inventory":["Invalid pk \"9\"."]}`},
+			expected: map[string]string{"name": "This field may not be blank.", "inventory": `Invalid pk "9".`},
+		},
+		{
+			name:     "several messages",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `{"name":["first","second"]}`},
+			expected: map[string]string{"name": "first\nsecond"},
+		},
+		{
+			name:     "nested fields",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `{"inputs":{"password":["This field is required."]}}`},
+			expected: map[string]string{"inputs.password": "This field is required."},
+		},
+		{
+			name:     "list of objects",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `{"survey_spec":[{"variable":["Duplicate."]},{}]}`},
+			expected: map[string]string{"survey_spec[0].variable": "Duplicate."},
+		},
+		{
+			name:     "string body",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `"Cannot launch."`},
+			expected: map[string]string{"": "Cannot launch."},
+		},
+		{
+			name:     "list body",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `["Cannot launch."]`},
+			expected: map[string]string{"": "Cannot launch."},
+		},
+		{
+			name:     "non string messages",
+			err:      &apiError{StatusCode: http.StatusBadRequest, Body: `{"forks":[5],"ignored":null}`},
+			expected: map[string]string{"forks": "5"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := awxValidationErrors(c.err); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("awxValidationErrors(%s) = %#v, expected %#v", c.err, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestMatchingAttribute(t *testing.T) {
+	config := testConfig(cty.ObjectVal(map[string]cty.Value{
+		"name":           cty.StringVal("test"),
+		"inventory_id":   cty.NumberIntVal(1),
+		"credential_ids": cty.ListValEmpty(cty.Number),
+		"inputs":         cty.StringVal("{}"),
+	}))
+
+	cases := []struct {
+		name     string
+		d        rawConfigReader
+		field    string
+		expected string
+	}{
+		{name: "same name", d: config, field: "name", expected: "name"},
+		{name: "id suffix", d: config, field: "inventory", expected: "inventory_id"},
+		{name: "plural", d: config, field: "credentials", expected: "credential_ids"},
+		{name: "nested field", d: config, field: "inputs.password", expected: "inputs"},
+		{name: "list item", d: config, field: "inputs[0].password", expected: "inputs"},
+		{name: "unknown field", d: config, field: "organization", expected: ""},
+		{name: "no field", d: config, field: "", expected: ""},
+		{name: "no configuration", d: nil, field: "name", expected: ""},
+		{name: "null configuration", d: testConfig(cty.NullVal(cty.EmptyObject)), field: "name", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := matchingAttribute(c.d, c.field); actual != c.expected {
+				t.Errorf("matchingAttribute(%q) = %q, expected %q", c.field, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestBuildDiagAPIFail(t *testing.T) {
+	config := testConfig(cty.ObjectVal(map[string]cty.Value{
+		"name":   cty.StringVal("test"),
+		"inputs": cty.StringVal("{}"),
+	}))
+	err := &apiError{StatusCode: http.StatusBadRequest, Body: `{"name":["Already exists."],"inputs":{"password":["Required."]},"kind":["Invalid."]}`}
+
+	diags := buildDiagAPIFail("Unable to create Credential", "Credential failed to create", err, config)
+	expected := []struct {
+		detail string
+		path   cty.Path
+	}{
+		{detail: "Credential failed to create: inputs.password: Required.", path: cty.GetAttrPath("inputs")},
+		{detail: "Credential failed to create: kind: Invalid."},
+		{detail: "Credential failed to create: Already exists.", path: cty.GetAttrPath("name")},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("buildDiagAPIFail returned %d diagnostics, expected %d: %v", len(diags), len(expected), diags)
+	}
+	for i, e := range expected {
+		if diags[i].Detail != e.detail || !diags[i].AttributePath.Equals(e.path) {
+			t.Errorf("diagnostic %d = %q at %#v, expected %q at %#v", i, diags[i].Detail, diags[i].AttributePath, e.detail, e.path)
+		}
+	}
+
+	diags = buildDiagAPIFail("Unable to launch", "Launch failed", &apiError{StatusCode: http.StatusBadRequest, Body: "Bad Request"}, nil)
+	if len(diags) != 1 || diags[0].Detail != "Launch failed: AWX rejected the request: Bad Request" {
+		t.Errorf("buildDiagAPIFail of a plain body = %v", diags)
+	}
+}
+
+func TestBadRequestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("{\n  \"inputs\": {\"password\": [\"Required.\"]}\n}"))
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient(context.Background(), httpClientConfig{maxRetries: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requester := &awx.Requester{Base: server.URL, Authenticator: &awx.BasicAuth{}, Client: client}
+
+	// Through goawx, as the services do.
+	_, err = requester.PostJSON("/api/v2/credentials/", nil, nil, nil)
+	if body, ok := badRequestBody(err); !ok || body != `{"inputs":{"password":["Required."]}}` {
+		t.Errorf("badRequestBody of goawx = %q, %t", body, ok)
+	}
+
+	// Through apiClient, with the endpoint of the request.
+	err = (&apiClient{requester: requester}).postJSON("/api/v2/credentials/", map[string]string{}, nil)
+	expected := &apiError{StatusCode: http.StatusBadRequest, Endpoint: "/api/v2/credentials/", Body: `{"inputs":{"password":["Required."]}}`}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("postJSON error = %#v, expected %#v", err, expected)
+	}
+}
@@ -0,0 +1,361 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultUserAgent = "terraform-provider-awx"
+
+// httpClientConfig holds the provider settings used to build the http client
+// of a provider instance.
+type httpClientConfig struct {
+	insecure   bool
+	caCertFile string
+	caCertPEM  string
+	clientCert string
+	clientKey  string
+	proxyURL   string
+	timeout    time.Duration
+	userAgent  string
+
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	maxConcurrentRequests int
+	requestsPerSecond     float64
+
+	// logSecrets are masked in the API logs wherever they show up.
+	logSecrets []string
+}
+
+// newHTTPClient builds a dedicated http client, so that the settings of a
+// provider instance never leak into http.DefaultClient or aliased instances.
+// ctx is the provider configuration context, the API logger of the transports
+// is derived from it once.
+func newHTTPClient(ctx context.Context, config httpClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.insecure}
+
+	if config.caCertFile != "" || config.caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if config.caCertFile != "" {
+			pem, err := os.ReadFile(config.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read ca_cert_file: %s", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in ca_cert_file %s", config.caCertFile)
+			}
+		}
+		if config.caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(config.caCertPEM)) {
+			return nil, fmt.Errorf("no certificate found in ca_cert_pem")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.clientCert != "" || config.clientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(config.clientCert), []byte(config.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client_cert and client_key: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if config.proxyURL != "" {
+		proxy, err := url.Parse(config.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	userAgent := config.userAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+
+	logCtx := newAPILogContext(ctx, config.logSecrets)
+	var roundTripper http.RoundTripper = &loggingTransport{
+		next:  transport,
+		ctx:   logCtx,
+		debug: apiDebugEnabled(),
+	}
+	if config.timeout > 0 {
+		roundTripper = &timeoutTransport{next: roundTripper, timeout: config.timeout}
+	}
+	if config.maxConcurrentRequests > 0 || config.requestsPerSecond > 0 {
+		throttle := &throttleTransport{next: roundTripper}
+		if config.maxConcurrentRequests > 0 {
+			throttle.slots = make(chan struct{}, config.maxConcurrentRequests)
+		}
+		if config.requestsPerSecond > 0 {
+			throttle.interval = time.Duration(float64(time.Second) / config.requestsPerSecond)
+		}
+		roundTripper = throttle
+	}
+	if config.maxRetries > 0 {
+		roundTripper = &retryTransport{
+			next:       roundTripper,
+			logCtx:     logCtx,
+			maxRetries: config.maxRetries,
+			waitMin:    config.retryWaitMin,
+			waitMax:    config.retryWaitMax,
+		}
+	}
+
+	// The timeout is applied by timeoutTransport to every attempt, as the
+	// Timeout of http.Client would bound the retries and their backoff too.
+	// Outside of retryTransport, which retries errors but never 400 responses.
+	roundTripper = &badRequestTransport{next: roundTripper}
+
+	return &http.Client{
+		Transport: &userAgentTransport{next: roundTripper, userAgent: userAgent},
+	}, nil
+}
+
+// userAgentTransport sets the User-Agent header of every request.
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// badRequestTransport turns 400 responses into an apiError holding their raw
+// body. goawx decodes them as a map of string lists and drops everything else,
+// while the error of the transport reaches the callers of goawx as is.
+type badRequestTransport struct {
+	next http.RoundTripper
+}
+
+func (t *badRequestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	raw := strings.TrimSpace(string(body))
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil {
+		raw = compact.String()
+	}
+	return nil, &apiError{StatusCode: resp.StatusCode, Endpoint: req.URL.Path, Body: raw}
+}
+
+// timeoutTransport bounds every attempt of a request, the read of its
+// response body included.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the context of a request once its response body
+// is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryTransport retries the requests that failed with a transient error,
+// waiting with a jittered exponential backoff between attempts.
+type retryTransport struct {
+	next http.RoundTripper
+	// logCtx holds the API logger, captured when the client is built since
+	// the requests of goawx carry none.
+	logCtx     context.Context
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.SubsystemWarn(t.logCtx, apiLogSubsystem, "Retrying AWX API request", fields)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// backoff returns how long to wait before the next attempt, honouring the
+// Retry-After header sent with 429 and 503 responses.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait := time.Duration(seconds) * time.Second
+			if wait > t.waitMax {
+				return t.waitMax
+			}
+			return wait
+		}
+	}
+
+	wait := t.waitMin << uint(attempt)
+	if wait < t.waitMin || wait > t.waitMax {
+		wait = t.waitMax
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// shouldRetry reports whether a request failed with an error worth retrying.
+// Requests that may have been applied by AWX are only retried when idempotent.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusConflict, http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// throttleTransport bounds the number of requests in flight and spaces out
+// requests to honour a maximum rate. Being shared by every resource of a
+// provider instance, it throttles the provider regardless of the Terraform
+// parallelism.
+type throttleTransport struct {
+	next     http.RoundTripper
+	slots    chan struct{}
+	interval time.Duration
+
+	mu     sync.Mutex
+	nextAt time.Time
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.interval > 0 {
+		select {
+		case <-time.After(t.reserve()):
+		case <-ctx.Done():
+			t.release()
+			return nil, ctx.Err()
+		}
+	}
+
+	// AWX renders the whole response before sending its headers, the slot can
+	// be released without waiting for the body to be read.
+	defer t.release()
+	return t.next.RoundTrip(req)
+}
+
+// reserve books the next request slot allowed by the rate and returns how
+// long to wait for it.
+func (t *throttleTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	if t.nextAt.Before(now) {
+		t.nextAt = now
+	}
+	wait := t.nextAt.Sub(now)
+	t.nextAt = t.nextAt.Add(t.interval)
+	return wait
+}
+
+func (t *throttleTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
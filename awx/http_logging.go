@@ -0,0 +1,219 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// apiLogSubsystem logs every API call, its level is set with TF_LOG_PROVIDER_AWX_API.
+	apiLogSubsystem = "api"
+	// apiLogMaxBody is the number of bytes of a body written to the logs.
+	apiLogMaxBody = 16 * 1024
+	redacted      = "REDACTED"
+)
+
+// sensitiveFields are the fields redacted wherever they appear in a body.
+// Any field containing password or secret is redacted as well.
+var sensitiveFields = map[string]bool{
+	"ssh_key_data":       true,
+	"ssh_key_unlock":     true,
+	"token":              true,
+	"oauth_token":        true,
+	"refresh_token":      true,
+	"security_token":     true,
+	"authorize_password": true,
+	// inputs of credentials hold their secrets, whatever the credential type.
+	"inputs": true,
+}
+
+// newAPILogContext returns ctx with the logging subsystem of the API calls,
+// masking the given secrets wherever they show up.
+func newAPILogContext(ctx context.Context, secrets []string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_AWX", "API"))
+	var masked []string
+	for _, secret := range secrets {
+		if secret != "" {
+			masked = append(masked, secret)
+		}
+	}
+	if len(masked) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, apiLogSubsystem, masked...)
+	}
+	return ctx
+}
+
+// apiLogLevelEnvVars are the variables setting the level of the API logs, in
+// decreasing precedence.
+var apiLogLevelEnvVars = []string{"TF_LOG_PROVIDER_AWX_API", "TF_LOG_PROVIDER_AWX", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// apiDebugEnabled reports whether the API logs are written, that is whether
+// their level is DEBUG or below. tflog offers no way to read it.
+func apiDebugEnabled() bool {
+	for _, envVar := range apiLogLevelEnvVars {
+		if level := strings.ToUpper(os.Getenv(envVar)); level != "" {
+			return level == "DEBUG" || level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
+// loggingTransport logs the method, URL, status, latency and bodies of every
+// request sent to AWX, with the sensitive fields of the bodies redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+	ctx  context.Context
+	// debug tells whether the logs are written at all, the bodies are only
+	// buffered when they are.
+	debug bool
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.debug {
+		return t.next.RoundTrip(req)
+	}
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.Redacted(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := t.requestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		req = body.req
+		fields["request_body"] = redactBody(body.content, req.Header.Get("Content-Type"))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(t.ctx, apiLogSubsystem, "AWX API request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+	fields["response_body"] = redactBody(content, resp.Header.Get("Content-Type"))
+	tflog.SubsystemDebug(t.ctx, apiLogSubsystem, "AWX API request", fields)
+	return resp, nil
+}
+
+type loggedRequest struct {
+	req     *http.Request
+	content []byte
+}
+
+// requestBody reads the body of req, and returns a request able to send it.
+func (t *loggingTransport) requestBody(req *http.Request) (loggedRequest, error) {
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return loggedRequest{}, err
+		}
+		defer body.Close()
+		content, err := io.ReadAll(body)
+		return loggedRequest{req: req, content: content}, err
+	}
+
+	content, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return loggedRequest{}, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	return loggedRequest{req: req, content: content}, nil
+}
+
+// redactBody returns the body to log: JSON and form bodies with their
+// sensitive fields redacted, anything else as is, truncated to apiLogMaxBody.
+func redactBody(content []byte, contentType string) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	var body string
+	var document interface{}
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(content))
+		if err != nil {
+			return redacted
+		}
+		for key := range values {
+			if isSensitiveField(key) {
+				values[key] = []string{redacted}
+			}
+		}
+		body = values.Encode()
+	case json.Unmarshal(content, &document) == nil:
+		redactedDocument, err := json.Marshal(redactJSON(document))
+		if err != nil {
+			return redacted
+		}
+		body = string(redactedDocument)
+	default:
+		body = string(content)
+	}
+
+	if len(body) > apiLogMaxBody {
+		return body[:apiLogMaxBody] + "...(truncated)"
+	}
+	return body
+}
+
+func redactJSON(document interface{}) interface{} {
+	switch value := document.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitiveField(key) && !isEmptyJSON(field) {
+				value[key] = redacted
+				continue
+			}
+			value[key] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactJSON(item)
+		}
+	}
+	return document
+}
+
+func isSensitiveField(key string) bool {
+	key = strings.ToLower(key)
+	return sensitiveFields[key] || strings.Contains(key, "password") || strings.Contains(key, "secret")
+}
+
+// isEmptyJSON reports whether a field holds nothing worth hiding, such as the
+// false of a password_needed flag, keeping the logs useful.
+func isEmptyJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil, bool, float64:
+		return true
+	case string:
+		return v == ""
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package awx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		name        string
+		content     string
+		contentType string
+		expected    string
+	}{
+		{
+			name:     "empty",
+			content:  "",
+			expected: "",
+		},
+		{
+			name:        "json sensitive fields",
+			content:     `{"name":"machine","password":"hunter2","ssh_key_data":"-----BEGIN","become_password":""}`,
+			contentType: "application/json",
+			expected:    `{"become_password":"","name":"machine","password":"REDACTED","ssh_key_data":"REDACTED"}`,
+		},
+		{
+			name:        "json nested fields",
+			content:     `{"results":[{"id":1,"inputs":{"username":"admin","password":"hunter2"}},{"id":2,"client_secret":"s3cr3t"}]}`,
+			contentType: "application/json",
+			expected:    `{"results":[{"id":1,"inputs":"REDACTED"},{"client_secret":"REDACTED","id":2}]}`,
+		},
+		{
+			name:        "json flags are kept",
+			content:     `{"ssh_password_needed":false,"Vault_Password":null}`,
+			contentType: "application/json",
+			expected:    `{"Vault_Password":null,"ssh_password_needed":false}`,
+		},
+		{
+			name:     "json without content type",
+			content:  `{"token":"abc"}`,
+			expected: `{"token":"REDACTED"}`,
+		},
+		{
+			name:        "form",
+			content:     "grant_type=password&username=admin&password=hunter2",
+			contentType: "application/x-www-form-urlencoded; charset=utf-8",
+			expected:    "grant_type=password&password=REDACTED&username=admin",
+		},
+		{
+			name:        "text",
+			content:     "PLAY [all] ***",
+			contentType: "text/plain",
+			expected:    "PLAY [all] ***",
+		},
+		{
+			name:        "truncated",
+			content:     strings.Repeat("x", apiLogMaxBody+1),
+			contentType: "text/plain",
+			expected:    strings.Repeat("x", apiLogMaxBody) + "...(truncated)",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := redactBody([]byte(c.content), c.contentType); actual != c.expected {
+				t.Errorf("redactBody(%q) = %q, expected %q", c.content, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestAPIDebugEnabled(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "unset", expected: false},
+		{name: "tf_log debug", env: map[string]string{"TF_LOG": "debug"}, expected: true},
+		{name: "tf_log info", env: map[string]string{"TF_LOG": "INFO"}, expected: false},
+		{name: "subsystem wins", env: map[string]string{"TF_LOG": "TRACE", "TF_LOG_PROVIDER_AWX_API": "WARN"}, expected: false},
+		{name: "provider", env: map[string]string{"TF_LOG_PROVIDER": "TRACE"}, expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, envVar := range apiLogLevelEnvVars {
+				t.Setenv(envVar, c.env[envVar])
+			}
+			if actual := apiDebugEnabled(); actual != c.expected {
+				t.Errorf("apiDebugEnabled() = %t, expected %t", actual, c.expected)
+			}
+		})
+	}
+}
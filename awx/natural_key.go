@@ -0,0 +1,149 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// naturalKeySeparator separates the names of a natural key, the name of the
+// parent objects first, such as Default/My Template.
+const naturalKeySeparator = "/"
+
+// naturalKey describes how the objects of an AWX endpoint are found by name.
+type naturalKey struct {
+	endpoint string
+	// filters are the list filters matching each name of the key, the
+	// outermost parent first and the name of the object itself last.
+	filters []string
+	// namedURL tells whether AWX serves the objects under a named URL made of
+	// the same names, innermost first, such as My Template++Default.
+	namedURL bool
+}
+
+var (
+	organizationKey         = naturalKey{endpoint: "/api/v2/organizations/", filters: []string{"name"}, namedURL: true}
+	instanceGroupKey        = naturalKey{endpoint: "/api/v2/instance_groups/", filters: []string{"name"}, namedURL: true}
+	userKey                 = naturalKey{endpoint: "/api/v2/users/", filters: []string{"username"}, namedURL: true}
+	teamKey                 = naturalKey{endpoint: "/api/v2/teams/", filters: []string{"organization__name", "name"}, namedURL: true}
+	projectKey              = naturalKey{endpoint: "/api/v2/projects/", filters: []string{"organization__name", "name"}, namedURL: true}
+	inventoryKey            = naturalKey{endpoint: "/api/v2/inventories/", filters: []string{"organization__name", "name"}, namedURL: true}
+	jobTemplateKey          = naturalKey{endpoint: "/api/v2/job_templates/", filters: []string{"organization__name", "name"}, namedURL: true}
+	workflowJobTemplateKey  = naturalKey{endpoint: "/api/v2/workflow_job_templates/", filters: []string{"organization__name", "name"}, namedURL: true}
+	notificationTemplateKey = naturalKey{endpoint: "/api/v2/notification_templates/", filters: []string{"organization__name", "name"}, namedURL: true}
+	executionEnvironmentKey = naturalKey{endpoint: "/api/v2/execution_environments/", filters: []string{"organization__name", "name"}, namedURL: true}
+	applicationKey          = naturalKey{endpoint: "/api/v2/applications/", filters: []string{"organization__name", "name"}, namedURL: true}
+	hostKey                 = naturalKey{endpoint: "/api/v2/hosts/", filters: []string{"inventory__organization__name", "inventory__name", "name"}, namedURL: true}
+	inventoryGroupKey       = naturalKey{endpoint: "/api/v2/groups/", filters: []string{"inventory__organization__name", "inventory__name", "name"}, namedURL: true}
+	inventorySourceKey      = naturalKey{endpoint: "/api/v2/inventory_sources/", filters: []string{"inventory__organization__name", "inventory__name", "name"}, namedURL: true}
+	workflowNodeKey         = naturalKey{endpoint: "/api/v2/workflow_job_template_nodes/", filters: []string{"workflow_job_template__organization__name", "workflow_job_template__name", "identifier"}, namedURL: true}
+	// The named URLs of credentials and credential types include the kind of
+	// the credential type, which is left out of their keys.
+	credentialKey     = naturalKey{endpoint: "/api/v2/credentials/", filters: []string{"organization__name", "name"}}
+	credentialTypeKey = naturalKey{endpoint: "/api/v2/credential_types/", filters: []string{"name"}}
+	scheduleKey       = naturalKey{endpoint: "/api/v2/schedules/", filters: []string{"unified_job_template__name", "name"}}
+)
+
+// resolve returns the ID of the object value names. A numeric value is taken
+// as an ID first, and only looked up as a name when no object has that ID.
+// The named URL is used when every name of the key is given, the list filters
+// when the outermost names are left out, such as inventory/host for a host.
+func (k naturalKey) resolve(c *apiClient, value string) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil && id > 0 {
+		err := c.getJSON(k.endpoint+strconv.Itoa(id)+"/", &struct{}{}, map[string]string{})
+		if err == nil {
+			return id, nil
+		}
+		if !isNotFound(err) {
+			return 0, err
+		}
+	}
+
+	names := strings.Split(value, naturalKeySeparator)
+	if len(names) > len(k.filters) {
+		return 0, fmt.Errorf("%q has more names than %s", value, k.format())
+	}
+
+	id, found, err := k.lookup(c, names)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("no object of %s has the ID or the name %q", k.endpoint, value)
+	}
+	return id, nil
+}
+
+func (k naturalKey) lookup(c *apiClient, names []string) (int, bool, error) {
+	var result struct {
+		ID int `json:"id"`
+	}
+	if k.namedURL && len(names) == len(k.filters) && !strings.Contains(strings.Join(names, ""), "+") {
+		escaped := make([]string, len(names))
+		for i, name := range names {
+			escaped[len(names)-1-i] = url.PathEscape(name)
+		}
+		err := c.getJSON(k.endpoint+strings.Join(escaped, "++")+"/", &result, map[string]string{})
+		if err == nil {
+			return result.ID, true, nil
+		}
+		if !isNotFound(err) {
+			return 0, false, err
+		}
+		return 0, false, nil
+	}
+
+	var list struct {
+		Count   int `json:"count"`
+		Results []struct {
+			ID int `json:"id"`
+		} `json:"results"`
+	}
+	params := map[string]string{}
+	filters := k.filters[len(k.filters)-len(names):]
+	for i, name := range names {
+		params[filters[i]] = name
+	}
+	if err := c.getJSON(k.endpoint, &list, params); err != nil {
+		return 0, false, err
+	}
+	switch {
+	case list.Count == 0 || len(list.Results) == 0:
+		return 0, false, nil
+	case list.Count > 1:
+		return 0, false, fmt.Errorf("%q matches %d objects of %s, give the names of its parents as in %s", strings.Join(names, naturalKeySeparator), list.Count, k.endpoint, k.format())
+	}
+	return list.Results[0].ID, true, nil
+}
+
+// format describes the key for error messages, such as <organization>/<name>.
+func (k naturalKey) format() string {
+	parts := make([]string, len(k.filters))
+	for i, filter := range k.filters {
+		fields := strings.Split(filter, "__")
+		if len(fields) > 1 {
+			fields = fields[:len(fields)-1]
+		}
+		parts[i] = "<" + fields[len(fields)-1] + ">"
+	}
+	return strings.Join(parts, naturalKeySeparator)
+}
+
+// importStateNaturalKey returns the importer of a resource imported by its ID
+// or by its natural key.
+func importStateNaturalKey(key naturalKey) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			id, err := key.resolve(apiClientFor(m), d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(strconv.Itoa(id))
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
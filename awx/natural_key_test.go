@@ -0,0 +1,121 @@
+package awx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	awx "github.com/denouche/goawx/client"
+)
+
+// newTestAPIClient returns an apiClient served by responses, keyed by escaped
+// path and query. Any other request gets a 404.
+func newTestAPIClient(t *testing.T, responses map[string]string) *apiClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			key += "?" + r.URL.RawQuery
+		}
+		body, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"Not found."}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return &apiClient{requester: &awx.Requester{
+		Base:          server.URL,
+		Authenticator: &awx.BasicAuth{},
+		Client:        server.Client(),
+	}}
+}
+
+func TestNaturalKeyResolve(t *testing.T) {
+	responses := map[string]string{
+		"/api/v2/job_templates/42/":                                    `{"id":42}`,
+		"/api/v2/job_templates/?name=1984":                             `{"count":1,"results":[{"id":7}]}`,
+		"/api/v2/job_templates/My%20Template++Default/":                `{"id":5}`,
+		"/api/v2/job_templates/Deploy%20100%25++Default/":              `{"id":6}`,
+		"/api/v2/job_templates/?name=a%2Bb&organization__name=Default": `{"count":1,"results":[{"id":8}]}`,
+		"/api/v2/job_templates/?name=Shared":                           `{"count":2,"results":[{"id":9},{"id":10}]}`,
+		"/api/v2/job_templates/?name=Unique":                           `{"count":1,"results":[{"id":11}]}`,
+		"/api/v2/hosts/web1++Inventory++Default/":                      `{"id":12}`,
+		"/api/v2/hosts/?inventory__name=Inventory&name=web2":           `{"count":1,"results":[{"id":13}]}`,
+		"/api/v2/credentials/?name=Machine&organization__name=Default": `{"count":1,"results":[{"id":14}]}`,
+	}
+	client := newTestAPIClient(t, responses)
+
+	cases := []struct {
+		name     string
+		key      naturalKey
+		value    string
+		expected int
+		err      string
+	}{
+		{name: "id", key: jobTemplateKey, value: "42", expected: 42},
+		{name: "numeric name", key: jobTemplateKey, value: "1984", expected: 7},
+		{name: "named url", key: jobTemplateKey, value: "Default/My Template", expected: 5},
+		{name: "named url escaping", key: jobTemplateKey, value: "Default/Deploy 100%", expected: 6},
+		{name: "plus in name", key: jobTemplateKey, value: "Default/a+b", expected: 8},
+		{name: "name only", key: jobTemplateKey, value: "Unique", expected: 11},
+		{name: "ambiguous name", key: jobTemplateKey, value: "Shared", err: `"Shared" matches 2 objects of /api/v2/job_templates/, give the names of its parents as in <organization>/<name>`},
+		{name: "not found", key: jobTemplateKey, value: "Default/Missing", err: `no object of /api/v2/job_templates/ has the ID or the name "Default/Missing"`},
+		{name: "too many names", key: jobTemplateKey, value: "a/b/c", err: `"a/b/c" has more names than <organization>/<name>`},
+		{name: "nested named url", key: hostKey, value: "Default/Inventory/web1", expected: 12},
+		{name: "nested partial key", key: hostKey, value: "Inventory/web2", expected: 13},
+		{name: "without named url", key: credentialKey, value: "Default/Machine", expected: 14},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := c.key.resolve(client, c.value)
+			if c.err != "" {
+				if err == nil || err.Error() != c.err {
+					t.Errorf("resolve(%q) error = %v, expected %q", c.value, err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolve(%q) error = %s", c.value, err)
+			}
+			if actual != c.expected {
+				t.Errorf("resolve(%q) = %d, expected %d", c.value, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestNaturalKeyFormat(t *testing.T) {
+	cases := []struct {
+		name     string
+		key      naturalKey
+		expected string
+	}{
+		{name: "organization", key: organizationKey, expected: "<name>"},
+		{name: "user", key: userKey, expected: "<username>"},
+		{name: "job template", key: jobTemplateKey, expected: "<organization>/<name>"},
+		{name: "host", key: hostKey, expected: "<organization>/<inventory>/<name>"},
+		{name: "workflow node", key: workflowNodeKey, expected: "<organization>/<workflow_job_template>/<identifier>"},
+		{name: "schedule", key: scheduleKey, expected: "<unified_job_template>/<name>"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := c.key.format(); actual != c.expected {
+				t.Errorf("format() = %q, expected %q", actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestNaturalKeyResolveServerError(t *testing.T) {
+	client := newTestAPIClient(t, map[string]string{})
+	client.requester.Base = "http://127.0.0.1:0"
+	if _, err := jobTemplateKey.resolve(client, "42"); err == nil || strings.Contains(err.Error(), "no object") {
+		t.Errorf("resolve(42) error = %v, expected the request error", err)
+	}
+}
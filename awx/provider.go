@@ -2,10 +2,11 @@ package awx
 
 import (
 	"context"
-	"crypto/tls"
 	"net/http"
+	"time"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -14,32 +15,137 @@ func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"hostname": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_HOSTNAME", "http://localhost"),
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     false,
 				Description: "Disable SSL verification of API calls",
 			},
-			"username": {
+			"config_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_USERNAME", "admin"),
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"CONTROLLER_CONFIG_FILE", "TOWER_CONFIG_FILE"}, ""),
+				Description: "Path of a tower_cli.cfg file of the awx.awx collection to read the settings from",
 			},
-			"password": {
+			"config_profile": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_PASSWORD", "password"),
+				Description: "Section of the tower_cli.cfg file to read the settings from, general by default",
 			},
-			"token": {
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a PEM encoded CA bundle used to verify the AWX certificate",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM encoded CA bundle used to verify the AWX certificate",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM encoded client certificate for mutual TLS authentication",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM encoded private key of client_cert",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the proxy used to reach AWX, instead of the HTTP_PROXY and HTTPS_PROXY environment variables",
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Timeout of each attempt of an API request in seconds, retries get a full timeout each, 0 means no timeout",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of times a request failing with a transient error is retried, 0 disables retries",
+			},
+			"retry_wait_min": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Minimum time to wait in seconds before retrying a request",
+			},
+			"retry_wait_max": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "Maximum time to wait in seconds before retrying a request",
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of API requests in flight at the same time, 0 means unlimited",
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of API requests sent per second, 0 means unlimited",
+			},
+			"user_agent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultUserAgent,
+				Description: "User-Agent header sent with every API request",
+			},
+			"api_path_prefix": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("AWX_TOKEN", ""),
+				Description: "Path of the controller API below hostname, such as /api/controller/ behind the AAP 2.5 gateway. Discovered through /api/ when unset",
+			},
+			"session_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Authenticate username and password through an AAP gateway session instead of basic auth",
+			},
+			"password_command": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"password"},
+				Description:   "Command printing the password on stdout, run again when AWX answers 401",
+			},
+			"token_command": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"token", "token_file"},
+				Description:   "Command printing the token on stdout, run again when AWX answers 401",
+			},
+			"token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"token", "token_command"},
+				Description:   "Path of a file holding the token, read again when AWX answers 401",
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -57,6 +163,7 @@ func Provider() *schema.Provider {
 			"awx_instance_group":                                      resourceInstanceGroup(),
 			"awx_inventory_group":                                     resourceInventoryGroup(),
 			"awx_inventory_source":                                    resourceInventorySource(),
+			"awx_inventory_source_update":                             resourceInventorySourceUpdateLaunch(),
 			"awx_inventory":                                           resourceInventory(),
 			"awx_job_template_credential":                             resourceJobTemplateCredentials(),
 			"awx_job_template":                                        resourceJobTemplate(),
@@ -66,14 +173,17 @@ func Provider() *schema.Provider {
 			"awx_job_template_notification_template_started":          resourceJobTemplateNotificationTemplateStarted(),
 			"awx_job_template_notification_template_success":          resourceJobTemplateNotificationTemplateSuccess(),
 			"awx_notification_template":                               resourceNotificationTemplate(),
+			"awx_oauth2_application":                                  resourceOAuth2Application(),
 			"awx_organization":                                        resourceOrganization(),
 			"awx_organization_instance_group":                         resourceOrganizationInstanceGroup(),
 			"awx_organization_galaxy_credential":                      resourceOrganizationsGalaxyCredentials(),
 			"awx_project":                                             resourceProject(),
+			"awx_project_update":                                      resourceProjectUpdateLaunch(),
 			"awx_schedule":                                            resourceSchedule(),
 			"awx_settings_ldap_team_map":                              resourceSettingsLDAPTeamMap(),
 			"awx_setting":                                             resourceSetting(),
 			"awx_team":                                                resourceTeam(),
+			"awx_token":                                               resourceToken(),
 			"awx_user":                                                resourceUser(),
 			"awx_workflow_job_template_node_always":                   resourceWorkflowJobTemplateNodeAlways(),
 			"awx_workflow_job_template_node_failure":                  resourceWorkflowJobTemplateNodeFailure(),
@@ -90,6 +200,7 @@ func Provider() *schema.Provider {
 			"awx_credential":                 dataSourceCredentialByID(),
 			"awx_credential_type":            dataSourceCredentialTypeByID(),
 			"awx_credentials":                dataSourceCredentials(),
+			"awx_config":                     dataSourceConfig(),
 			"awx_execution_environment":      dataSourceExecutionEnvironment(),
 			"awx_inventory_group":            dataSourceInventoryGroup(),
 			"awx_inventory":                  dataSourceInventory(),
@@ -110,27 +221,110 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	hostname := d.Get("hostname").(string)
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
-	token := d.Get("token").(string)
-
 	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
+	settings, secrets, diags := resolveProviderSettings(ctx, d)
+	if diags.HasError() {
+		return nil, diags
+	}
+	hostname := settings["hostname"]
+	username := settings["username"]
+	password := settings["password"]
+	token := settings["token"]
+
+	client, err := newHTTPClient(ctx, httpClientConfig{
+		insecure:   settings["insecure"] == "true",
+		caCertFile: d.Get("ca_cert_file").(string),
+		caCertPEM:  d.Get("ca_cert_pem").(string),
+		clientCert: d.Get("client_cert").(string),
+		clientKey:  d.Get("client_key").(string),
+		proxyURL:   d.Get("proxy_url").(string),
+		timeout:    time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		userAgent:  d.Get("user_agent").(string),
+
+		maxRetries:   d.Get("max_retries").(int),
+		retryWaitMin: time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		retryWaitMax: time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+
+		maxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		requestsPerSecond:     d.Get("requests_per_second").(float64),
+
+		logSecrets: []string{password, token, d.Get("client_key").(string)},
+	})
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create HTTP client",
+			Detail:   err.Error(),
+		})
+		return nil, diags
+	}
+
+	if token != "" && d.Get("session_auth").(bool) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Conflicting AWX authentication settings",
+			Detail:   "session_auth logs in with username and password, it cannot be used with token",
+		})
+		return nil, diags
+	}
 
-	client := http.DefaultClient
-	if d.Get("insecure").(bool) {
-		customTransport := http.DefaultTransport.(*http.Transport).Clone()
-		customTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-		client.Transport = customTransport
+	apiPathPrefix := d.Get("api_path_prefix").(string)
+	if apiPathPrefix == "" {
+		apiPathPrefix, err = discoverAPIPathPrefix(ctx, client, hostname)
+		if err != nil {
+			tflog.Warn(ctx, "Unable to discover the AWX API path prefix, using the default one", map[string]interface{}{
+				"prefix": defaultAPIPathPrefix,
+				"error":  err.Error(),
+			})
+			apiPathPrefix = defaultAPIPathPrefix
+		}
+	}
+	switch {
+	case d.Get("session_auth").(bool):
+		client.Transport = &gatewaySessionTransport{
+			next:           client.Transport,
+			hostname:       hostname,
+			username:       username,
+			password:       password,
+			passwordSource: secrets["password"],
+		}
+	case token != "" && secrets["token"] != nil:
+		client.Transport = &secretAuthTransport{
+			next:   client.Transport,
+			source: secrets["token"],
+			authorize: func(req *http.Request, secret string) {
+				req.Header.Set("Authorization", "Bearer "+secret)
+			},
+		}
+	case token == "" && secrets["password"] != nil:
+		client.Transport = &secretAuthTransport{
+			next:   client.Transport,
+			source: secrets["password"],
+			authorize: func(req *http.Request, secret string) {
+				req.SetBasicAuth(username, secret)
+			},
+		}
+	}
+	if apiPathPrefix = normalizeAPIPathPrefix(apiPathPrefix); apiPathPrefix != defaultAPIPathPrefix {
+		client.Transport, err = newAPIPathTransport(client.Transport, hostname, apiPathPrefix)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to create HTTP client",
+				Detail:   err.Error(),
+			})
+			return nil, diags
+		}
 	}
 
 	var c *awx.AWX
-	var err error
+	requester := &awx.Requester{Base: hostname, Client: client}
 	if token != "" {
 		c, err = awx.NewAWXToken(hostname, token, client)
+		requester.Authenticator = &awx.TokenAuth{Token: token}
 	} else {
 		c, err = awx.NewAWX(hostname, username, password, client)
+		requester.Authenticator = &awx.BasicAuth{Username: username, Password: password}
 	}
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -140,6 +334,18 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		})
 		return nil, diags
 	}
+	api := registerAPIClient(c, requester)
+	if err := api.loadServerInfo(); err != nil {
+		tflog.Warn(ctx, "Unable to detect the AWX version, version requirements are not checked", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		server := api.detectedServer()
+		tflog.Info(ctx, "Detected AWX version", map[string]interface{}{
+			"version":      server.Version,
+			"license_type": server.LicenseType,
+		})
+	}
 
 	return c, diags
 }
@@ -0,0 +1,300 @@
+package awx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultConfigProfile is the section tower_cli.cfg settings belong to when
+// the file has no section header, as in the awx.awx collection.
+const defaultConfigProfile = "general"
+
+// providerSetting is a provider argument that can also come from the
+// environment variables and the tower_cli.cfg files of the awx.awx collection.
+// Sources are looked up in this order, the first one set wins: the provider
+// block, the command and file arguments, envVars in their order, the config
+// file profile and fallback.
+type providerSetting struct {
+	attribute        string
+	commandAttribute string
+	fileAttribute    string
+	envVars          []string
+	configKey        string
+	fallback         string
+	// verifySSL marks the insecure setting, which the collection spells as
+	// its opposite verify_ssl outside of the provider block.
+	verifySSL bool
+	// supersededBy is the setting taking precedence over this one when both
+	// are set, in which case the command or file of this one is not read.
+	supersededBy string
+}
+
+var providerSettings = []providerSetting{
+	{
+		attribute: "hostname",
+		envVars:   []string{"AWX_HOSTNAME", "CONTROLLER_HOST", "TOWER_HOST"},
+		configKey: "host",
+		fallback:  "http://localhost",
+	},
+	{
+		attribute: "username",
+		envVars:   []string{"AWX_USERNAME", "CONTROLLER_USERNAME", "TOWER_USERNAME"},
+		configKey: "username",
+		fallback:  "admin",
+	},
+	{
+		attribute:        "password",
+		commandAttribute: "password_command",
+		envVars:          []string{"AWX_PASSWORD", "CONTROLLER_PASSWORD", "TOWER_PASSWORD"},
+		configKey:        "password",
+		fallback:         "password",
+		supersededBy:     "token",
+	},
+	{
+		attribute:        "token",
+		commandAttribute: "token_command",
+		fileAttribute:    "token_file",
+		envVars:          []string{"AWX_TOKEN", "CONTROLLER_OAUTH_TOKEN", "TOWER_OAUTH_TOKEN"},
+		configKey:        "oauth_token",
+	},
+	{
+		attribute: "insecure",
+		envVars:   []string{"CONTROLLER_VERIFY_SSL", "TOWER_VERIFY_SSL"},
+		configKey: "verify_ssl",
+		fallback:  "false",
+		verifySSL: true,
+	},
+}
+
+// settingValue is the value of a setting found in a source.
+type settingValue struct {
+	source string
+	value  string
+	// secret is set when the value comes from a command or a file, which is
+	// only read once the setting is known to be used.
+	secret *secretSource
+}
+
+// resolveProviderSettings returns the value of every providerSetting, and the
+// secretSource of those read from a command or a file, with a warning for
+// each source ignored while holding another value.
+func resolveProviderSettings(ctx context.Context, d *schema.ResourceData) (map[string]string, map[string]*secretSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	profile, profileSource, err := loadConfigProfile(d.Get("config_file").(string), d.Get("config_profile").(string))
+	if err != nil {
+		return nil, nil, buildDiagnosticsMessage(
+			"Unable to load the AWX config file",
+			"%s",
+			err.Error(),
+		)
+	}
+
+	settings := map[string]string{}
+	secrets := map[string]*secretSource{}
+	for _, setting := range providerSettings {
+		values, err := setting.values(ctx, d, profile, profileSource)
+		if err != nil {
+			return nil, nil, buildDiagnosticsMessage(
+				fmt.Sprintf("Invalid value for %s", setting.attribute),
+				"%s",
+				err.Error(),
+			)
+		}
+		if len(values) == 0 {
+			settings[setting.attribute] = setting.fallback
+			continue
+		}
+
+		settings[setting.attribute] = values[0].value
+		if values[0].secret != nil {
+			secrets[setting.attribute] = values[0].secret
+		}
+		for _, ignored := range values[1:] {
+			if ignored.value == values[0].value && values[0].secret == nil {
+				continue
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Conflicting values for the AWX provider %s", setting.attribute),
+				Detail:   fmt.Sprintf("%s is set by both %s and %s, the value of %s is used", setting.attribute, values[0].source, ignored.source, values[0].source),
+			})
+		}
+	}
+
+	for _, setting := range providerSettings {
+		secret, ok := secrets[setting.attribute]
+		if !ok {
+			continue
+		}
+		if superseding := setting.supersededBy; superseding != "" && (settings[superseding] != "" || secrets[superseding] != nil) {
+			settings[setting.attribute] = setting.fallback
+			delete(secrets, setting.attribute)
+			continue
+		}
+		value, err := secret.get(ctx)
+		if err != nil {
+			return nil, nil, buildDiagnosticsMessage(
+				fmt.Sprintf("Invalid value for %s", setting.attribute),
+				"%s",
+				err.Error(),
+			)
+		}
+		settings[setting.attribute] = value
+	}
+	return settings, secrets, diags
+}
+
+// values returns the values found for the setting, in decreasing precedence.
+func (s providerSetting) values(ctx context.Context, d *schema.ResourceData, profile map[string]string, profileSource string) ([]settingValue, error) {
+	var values []settingValue
+	if s.verifySSL {
+		// GetOkExists, as an explicit insecure = false must win over the other sources.
+		if v, ok := d.GetOkExists(s.attribute); ok {
+			values = append(values, settingValue{source: "the provider block", value: strconv.FormatBool(v.(bool))})
+		}
+	} else if v, ok := d.GetOk(s.attribute); ok {
+		values = append(values, settingValue{source: "the provider block", value: v.(string)})
+	}
+
+	var secrets []*secretSource
+	if s.commandAttribute != "" {
+		if v, ok := d.GetOk(s.commandAttribute); ok {
+			secrets = append(secrets, newCommandSecretSource(ctx, s.commandAttribute, v.(string)))
+		}
+	}
+	if s.fileAttribute != "" {
+		if v, ok := d.GetOk(s.fileAttribute); ok {
+			secrets = append(secrets, newFileSecretSource(ctx, s.fileAttribute, v.(string)))
+		}
+	}
+	for _, secret := range secrets {
+		values = append(values, settingValue{source: secret.name, secret: secret})
+	}
+
+	add := func(source, value string) error {
+		if s.verifySSL {
+			verify, err := parseConfigBool(value)
+			if err != nil {
+				return fmt.Errorf("%s: %s", source, err)
+			}
+			value = strconv.FormatBool(!verify)
+		}
+		values = append(values, settingValue{source: source, value: value})
+		return nil
+	}
+	for _, envVar := range s.envVars {
+		if v := os.Getenv(envVar); v != "" {
+			if err := add(envVar, v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if v := profile[s.configKey]; v != "" {
+		if err := add(fmt.Sprintf("%s in %s", s.configKey, profileSource), v); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// parseConfigBool parses a boolean the way the awx.awx collection does.
+func parseConfigBool(v string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "1", "true", "yes", "on", "y", "t":
+		return true, nil
+	case "0", "false", "no", "off", "n", "f":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a boolean", v)
+}
+
+// loadConfigProfile returns the settings of profile in a tower_cli.cfg file,
+// and the name of the source they come from. Nothing is loaded unless a file
+// or a profile is configured. Without a file, the first of ./tower_cli.cfg,
+// ~/.tower_cli.cfg and /etc/tower/tower_cli.cfg found is used.
+func loadConfigProfile(path, profile string) (map[string]string, string, error) {
+	if path == "" && profile == "" {
+		return nil, "", nil
+	}
+	if profile == "" {
+		profile = defaultConfigProfile
+	}
+	if path == "" {
+		path = findConfigFile()
+		if path == "" {
+			return nil, "", fmt.Errorf("config_profile %s is set but no tower_cli.cfg file was found", profile)
+		}
+	}
+
+	profiles, err := readConfigFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	settings, ok := profiles[profile]
+	if !ok {
+		return nil, "", fmt.Errorf("profile %s not found in %s", profile, path)
+	}
+	return settings, fmt.Sprintf("profile %s of %s", profile, path), nil
+}
+
+func findConfigFile() string {
+	candidates := []string{"tower_cli.cfg"}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".tower_cli.cfg"))
+	}
+	candidates = append(candidates, "/etc/tower/tower_cli.cfg")
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// readConfigFile parses an INI file into its sections. Settings before the
+// first section header belong to the general section.
+func readConfigFile(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %s", err)
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	section := defaultConfigProfile
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if colonKey, colonValue, colon := strings.Cut(line, ":"); colon && (!found || len(colonKey) < len(key)) {
+			key, value, found = colonKey, colonValue, true
+		}
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNumber)
+		}
+		if profiles[section] == nil {
+			profiles[section] = map[string]string{}
+		}
+		profiles[section][strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read config file: %s", err)
+	}
+	return profiles, nil
+}
@@ -51,6 +51,7 @@ func resourceCredential() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: importStateNaturalKey(credentialKey),
 	}
 }
 
@@ -82,12 +83,7 @@ func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m int
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credential",
-			Detail:   fmt.Sprintf("Unable to create new credential: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credential", "Unable to create new credential", err, d)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -103,17 +99,13 @@ func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m inter
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credential",
-			Detail:   fmt.Sprintf("Unable to credential with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential", id, err, d)
 	}
 
 	d.Set("name", cred.Name)
 	d.Set("description", cred.Description)
 	d.Set("organization_id", cred.OrganizationID)
+	d.Set("credential_type_id", cred.CredentialTypeID)
 	d.Set("inputs", cred.Inputs)
 
 	return diags
@@ -157,12 +149,7 @@ func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m int
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credentials", fmt.Sprintf("Unable to update existing credentials with id %d", id), err, d)
 		}
 	}
 
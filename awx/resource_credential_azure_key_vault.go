@@ -57,6 +57,7 @@ func resourceCredentialAzureKeyVault() *schema.Resource {
 				Required: true,
 			},
 		},
+		Importer: importStateNaturalKey(credentialKey),
 	}
 }
 
@@ -80,12 +81,7 @@ func resourceCredentialAzureKeyVaultCreate(ctx context.Context, d *schema.Resour
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credentials", "Unable to create new credentials", err, d)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -101,12 +97,7 @@ func resourceCredentialAzureKeyVaultRead(ctx context.Context, d *schema.Resource
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential", id, err, d)
 	}
 
 	d.Set("name", cred.Name)
@@ -121,8 +112,6 @@ func resourceCredentialAzureKeyVaultRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -152,12 +141,7 @@ func resourceCredentialAzureKeyVaultUpdate(ctx context.Context, d *schema.Resour
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credentials", fmt.Sprintf("Unable to update existing credentials with id %d", id), err, d)
 		}
 	}
 
@@ -53,6 +53,7 @@ func resourceCredentialGalaxy() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: importStateNaturalKey(credentialKey),
 	}
 }
 
@@ -75,12 +76,7 @@ func resourceCredentialGalaxyCreate(ctx context.Context, d *schema.ResourceData,
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credentials", "Unable to create new credentials", err, d)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -96,12 +92,7 @@ func resourceCredentialGalaxyRead(ctx context.Context, d *schema.ResourceData, m
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential", id, err, d)
 	}
 
 	d.Set("name", cred.Name)
@@ -115,8 +106,6 @@ func resourceCredentialGalaxyRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCredentialGalaxyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -147,12 +136,7 @@ func resourceCredentialGalaxyUpdate(ctx context.Context, d *schema.ResourceData,
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credentials", fmt.Sprintf("Unable to update existing credentials with id %d", id), err, d)
 		}
 	}
 
@@ -53,6 +53,7 @@ func resourceCredentialGoogleComputeEngine() *schema.Resource {
 				Sensitive: true,
 			},
 		},
+		Importer: importStateNaturalKey(credentialKey),
 	}
 }
 
@@ -75,12 +76,7 @@ func resourceCredentialGoogleComputeEngineCreate(ctx context.Context, d *schema.
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialsService.CreateCredentials(newCredential, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credentials", "Unable to create new credentials", err, d)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -96,12 +92,7 @@ func resourceCredentialGoogleComputeEngineRead(ctx context.Context, d *schema.Re
 	id, _ := strconv.Atoi(d.Id())
 	cred, err := client.CredentialsService.GetCredentialsByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential", id, err, d)
 	}
 
 	d.Set("name", cred.Name)
@@ -114,8 +105,6 @@ func resourceCredentialGoogleComputeEngineRead(ctx context.Context, d *schema.Re
 }
 
 func resourceCredentialGoogleComputeEngineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"name",
 		"description",
@@ -144,12 +133,7 @@ func resourceCredentialGoogleComputeEngineUpdate(ctx context.Context, d *schema.
 		client := m.(*awx.AWX)
 		_, err = client.CredentialsService.UpdateCredentialsByID(id, updatedCredential, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credentials", fmt.Sprintf("Unable to update existing credentials with id %d", id), err, d)
 		}
 	}
 
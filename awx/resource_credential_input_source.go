@@ -51,6 +51,9 @@ func resourceCredentialInputSource() *schema.Resource {
 				Optional: true,
 			},
 		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 	}
 }
 
@@ -69,12 +72,7 @@ func resourceCredentialInputSourceCreate(ctx context.Context, d *schema.Resource
 	client := m.(*awx.AWX)
 	cred, err := client.CredentialInputSourceService.CreateCredentialInputSource(newSourceInput, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credentials",
-			Detail:   fmt.Sprintf("Unable to create new credentials: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credentials", "Unable to create new credentials", err, d)
 	}
 
 	d.SetId(strconv.Itoa(cred.ID))
@@ -90,12 +88,7 @@ func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceDa
 	id, _ := strconv.Atoi(d.Id())
 	inputSource, err := client.CredentialInputSourceService.GetCredentialInputSourceByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credentials",
-			Detail:   fmt.Sprintf("Unable to credentials with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential input source", id, err, d)
 	}
 
 	d.Set("description", inputSource.Description)
@@ -108,8 +101,6 @@ func resourceCredentialInputSourceRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	keys := []string{
 		"description",
 		"input_field_name",
@@ -133,12 +124,7 @@ func resourceCredentialInputSourceUpdate(ctx context.Context, d *schema.Resource
 		client := m.(*awx.AWX)
 		_, err = client.CredentialInputSourceService.UpdateCredentialInputSourceByID(id, updatedSourceInput, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credentials",
-				Detail:   fmt.Sprintf("Unable to update existing credentials with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credentials", fmt.Sprintf("Unable to update existing credentials with id %d", id), err, d)
 		}
 	}
 
@@ -151,7 +137,7 @@ func resourceCredentialInputSourceDelete(ctx context.Context, d *schema.Resource
 	id, _ := strconv.Atoi(d.Id())
 	client := m.(*awx.AWX)
 	err := client.CredentialInputSourceService.DeleteCredentialInputSourceByID(id, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to delete existing credentials",
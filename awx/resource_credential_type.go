@@ -53,6 +53,7 @@ func resourceCredentialType() *schema.Resource {
 				Required: true,
 			},
 		},
+		Importer: importStateNaturalKey(credentialTypeKey),
 	}
 }
 
@@ -97,12 +98,7 @@ func resourceCredentialTypeCreate(ctx context.Context, d *schema.ResourceData, m
 	client := m.(*awx.AWX)
 	credtype, err := client.CredentialTypeService.CreateCredentialType(newCredentialType, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new credential type",
-			Detail:   fmt.Sprintf("Unable to create new credential type: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new credential type", "Unable to create new credential type", err, d)
 	}
 
 	d.SetId(strconv.Itoa(credtype.ID))
@@ -118,12 +114,7 @@ func resourceCredentialTypeRead(ctx context.Context, d *schema.ResourceData, m i
 	id, _ := strconv.Atoi(d.Id())
 	credtype, err := client.CredentialTypeService.GetCredentialTypeByID(id, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch credential type",
-			Detail:   fmt.Sprintf("Unable to credential type with id %d: %s", id, err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("credential type", id, err, d)
 	}
 
 	d.Set("name", credtype.Name)
@@ -185,12 +176,7 @@ func resourceCredentialTypeUpdate(ctx context.Context, d *schema.ResourceData, m
 		client := m.(*awx.AWX)
 		_, err = client.CredentialTypeService.UpdateCredentialTypeByID(id, updatedCredentialType, map[string]string{})
 		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update existing credential type",
-				Detail:   fmt.Sprintf("Unable to update existing credential type with id %d: %s", id, err.Error()),
-			})
-			return diags
+			return buildDiagAPIFail("Unable to update existing credential type", fmt.Sprintf("Unable to update existing credential type with id %d", id), err, d)
 		}
 	}
 
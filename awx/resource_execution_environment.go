@@ -29,6 +29,9 @@ func resourceExecutionEnvironment() *schema.Resource {
 		ReadContext:   resourceExecutionEnvironmentsRead,
 		UpdateContext: resourceExecutionEnvironmentsUpdate,
 		DeleteContext: resourceExecutionEnvironmentsDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			return requireFeature(m, featureExecutionEnvironments)
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,11 +58,11 @@ func resourceExecutionEnvironment() *schema.Resource {
 				Default:  "",
 			},
 		},
+		Importer: importStateNaturalKey(executionEnvironmentKey),
 	}
 }
 
 func resourceExecutionEnvironmentsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.ExecutionEnvironmentsService
 
@@ -72,12 +75,7 @@ func resourceExecutionEnvironmentsCreate(ctx context.Context, d *schema.Resource
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create ExecutionEnvironment %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create ExecutionEnvironments",
-			Detail:   fmt.Sprintf("ExecutionEnvironments with name, failed to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create ExecutionEnvironments", fmt.Sprintf("ExecutionEnvironments with name %s, failed to create", d.Get("name").(string)), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -97,7 +95,7 @@ func resourceExecutionEnvironmentsUpdate(ctx context.Context, d *schema.Resource
 
 	_, err := awxService.GetExecutionEnvironmentByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("ExecutionEnvironments", id, err)
+		return buildDiagFetchFail("ExecutionEnvironments", id, err)
 	}
 
 	_, err = awxService.UpdateExecutionEnvironment(id, map[string]interface{}{
@@ -108,12 +106,7 @@ func resourceExecutionEnvironmentsUpdate(ctx context.Context, d *schema.Resource
 		"credential":   AtoipOr(d.Get("credential").(string), nil),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update ExecutionEnvironments",
-			Detail:   fmt.Sprintf("ExecutionEnvironments with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update ExecutionEnvironments", fmt.Sprintf("ExecutionEnvironments with name %s failed to update", d.Get("name").(string)), err, d)
 	}
 
 	return resourceExecutionEnvironmentsRead(ctx, d, m)
@@ -130,7 +123,7 @@ func resourceExecutionEnvironmentsRead(ctx context.Context, d *schema.ResourceDa
 
 	res, err := awxService.GetExecutionEnvironmentByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("ExecutionEnvironment", id, err)
+		return buildDiagReadFail("ExecutionEnvironment", id, err, d)
 
 	}
 	d = setExecutionEnvironmentsResourceData(d, res)
@@ -147,7 +140,7 @@ func resourceExecutionEnvironmentsDelete(ctx context.Context, d *schema.Resource
 		return diags
 	}
 
-	if _, err := awxService.DeleteExecutionEnvironment(id); err != nil {
+	if _, err := awxService.DeleteExecutionEnvironment(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(digMessagePart, fmt.Sprintf("ExecutionEnvironmentID %v, got %s ", id, err.Error()))
 	}
 	d.SetId("")
@@ -158,8 +151,14 @@ func setExecutionEnvironmentsResourceData(d *schema.ResourceData, r *awx.Executi
 	d.Set("name", r.Name)
 	d.Set("image", r.Image)
 	d.Set("description", r.Description)
-	d.Set("organization", r.Organization)
-	d.Set("credential", r.Credential)
+	d.Set("organization", "")
+	if r.Organization != 0 {
+		d.Set("organization", strconv.Itoa(r.Organization))
+	}
+	d.Set("credential", "")
+	if r.Credential != 0 {
+		d.Set("credential", strconv.Itoa(r.Credential))
+	}
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
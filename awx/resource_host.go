@@ -76,9 +76,7 @@ func resourceHost() *schema.Resource {
 				StateFunc: normalizeJsonYaml,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(hostKey),
 	}
 }
 
@@ -96,7 +94,7 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, m interface
 		"variables":   d.Get("variables").(string),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagCreateFail(diagElementHostTitle, err)
+		return buildDiagCreateFail(diagElementHostTitle, err, d)
 	}
 
 	hostID := result.ID
@@ -137,7 +135,7 @@ func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"variables":   d.Get("variables").(string),
 	}, nil)
 	if err != nil {
-		return buildDiagUpdateFail(diagElementHostTitle, id, err)
+		return buildDiagUpdateFail(diagElementHostTitle, id, err, d)
 	}
 
 	if d.HasChange("group_ids") {
@@ -169,7 +167,7 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 	res, err := awxService.GetHostByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail(diagElementHostTitle, id, err)
+		return buildDiagReadFail(diagElementHostTitle, id, err, d)
 	}
 	d = setHostResourceData(d, res)
 	return nil
@@ -183,7 +181,7 @@ func resourceHostDelete(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	if _, err := awxService.DeleteHost(id); err != nil {
+	if _, err := awxService.DeleteHost(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementHostTitle,
 			fmt.Sprintf("id %v, got %s ",
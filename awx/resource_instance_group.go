@@ -54,9 +54,7 @@ func resourceInstanceGroup() *schema.Resource {
 				StateFunc: normalizeJsonYaml,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(instanceGroupKey),
 	}
 }
 
@@ -73,7 +71,7 @@ func resourceInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, m
 		"pod_spec_override":          d.Get("pod_spec_override").(string),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagCreateFail(diagElementInstanceGroupTitle, err)
+		return buildDiagCreateFail(diagElementInstanceGroupTitle, err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -97,7 +95,7 @@ func resourceInstanceGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 		"pod_spec_override":          d.Get("pod_spec_override").(string),
 	}, nil)
 	if err != nil {
-		return buildDiagUpdateFail(diagElementInstanceGroupTitle, id, err)
+		return buildDiagUpdateFail(diagElementInstanceGroupTitle, id, err, d)
 	}
 
 	return resourceInstanceGroupRead(ctx, d, m)
@@ -113,7 +111,7 @@ func resourceInstanceGroupDelete(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	if _, err := awxService.DeleteInstanceGroup(id); err != nil {
+	if _, err := awxService.DeleteInstanceGroup(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementInstanceGroupTitle,
 			fmt.Sprintf("ID: %v, got %s ",
@@ -135,7 +133,7 @@ func resourceInstanceGroupRead(ctx context.Context, d *schema.ResourceData, m in
 
 	res, err := awxService.GetInstanceGroupByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail(diagElementInstanceGroupTitle, id, err)
+		return buildDiagReadFail(diagElementInstanceGroupTitle, id, err, d)
 	}
 	d = setInstanceGroupResourceData(d, res)
 	return diags
@@ -38,6 +38,12 @@ func resourceInventory() *schema.Resource {
 		ReadContext:   resourceInventoryRead,
 		DeleteContext: resourceInventoryDelete,
 		UpdateContext: resourceInventoryUpdate,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if d.Get("kind").(string) != "constructed" {
+				return nil
+			}
+			return requireFeature(m, featureConstructedInventories)
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -70,9 +76,7 @@ func resourceInventory() *schema.Resource {
 				StateFunc: normalizeJsonYaml,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(inventoryKey),
 	}
 }
 
@@ -89,7 +93,7 @@ func resourceInventoryCreate(ctx context.Context, d *schema.ResourceData, m inte
 		"variables":    d.Get("variables").(string),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagCreateFail(diagElementInventoryTitle, err)
+		return buildDiagCreateFail(diagElementInventoryTitle, err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -113,7 +117,7 @@ func resourceInventoryUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		"variables":    d.Get("variables").(string),
 	}, nil)
 	if err != nil {
-		return buildDiagUpdateFail(diagElementInventoryTitle, id, err)
+		return buildDiagUpdateFail(diagElementInventoryTitle, id, err, d)
 	}
 
 	return resourceInventoryRead(ctx, d, m)
@@ -130,7 +134,7 @@ func resourceInventoryRead(ctx context.Context, d *schema.ResourceData, m interf
 	}
 	r, err := awxService.GetInventory(id, map[string]string{})
 	if err != nil {
-		return buildDiagNotFoundFail(diagElementInventoryTitle, id, err)
+		return buildDiagReadFail(diagElementInventoryTitle, id, err, d)
 	}
 	d = setInventoryResourceData(d, r)
 	return nil
@@ -143,7 +147,7 @@ func resourceInventoryDelete(ctx context.Context, d *schema.ResourceData, m inte
 	if diags.HasError() {
 		return diags
 	}
-	if _, err := awxService.DeleteInventory(id); err != nil {
+	if _, err := awxService.DeleteInventory(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementInventoryTitle,
 			fmt.Sprintf(
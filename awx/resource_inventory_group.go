@@ -49,9 +49,7 @@ func resourceInventoryGroup() *schema.Resource {
 				StateFunc: normalizeJsonYaml,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(inventoryGroupKey),
 	}
 }
 
@@ -67,7 +65,7 @@ func resourceInventoryGroupCreate(ctx context.Context, d *schema.ResourceData, m
 		"variables":   d.Get("variables").(string),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagCreateFail(diagElementInventoryGroupTitle, err)
+		return buildDiagCreateFail(diagElementInventoryGroupTitle, err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -90,7 +88,7 @@ func resourceInventoryGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 		"variables":   d.Get("variables").(string),
 	}, nil)
 	if err != nil {
-		return buildDiagUpdateFail(diagElementInventoryGroupTitle, id, err)
+		return buildDiagUpdateFail(diagElementInventoryGroupTitle, id, err, d)
 	}
 
 	return resourceInventoryGroupRead(ctx, d, m)
@@ -106,7 +104,7 @@ func resourceInventoryGroupDelete(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	if _, err := awxService.DeleteGroup(id); err != nil {
+	if _, err := awxService.DeleteGroup(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementInventoryGroupTitle,
 			fmt.Sprintf("ID: %v, got %s ",
@@ -128,7 +126,7 @@ func resourceInventoryGroupRead(ctx context.Context, d *schema.ResourceData, m i
 
 	res, err := awxService.GetGroupByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail(diagElementInventoryGroupTitle, id, err)
+		return buildDiagReadFail(diagElementInventoryGroupTitle, id, err, d)
 	}
 	d = setInventoryGroupResourceData(d, res)
 	return diags
@@ -114,9 +114,7 @@ func resourceInventorySource() *schema.Resource {
 				Optional: true,
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(inventorySourceKey),
 	}
 }
 
@@ -154,7 +152,7 @@ func resourceInventorySourceCreate(ctx context.Context, d *schema.ResourceData,
 
 	result, err := awxService.CreateInventorySource(createInventorySourceData, map[string]string{})
 	if err != nil {
-		return buildDiagCreateFail(diagElementInventorySourceTitle, err)
+		return buildDiagCreateFail(diagElementInventorySourceTitle, err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -200,7 +198,7 @@ func resourceInventorySourceUpdate(ctx context.Context, d *schema.ResourceData,
 
 	_, err := awxService.UpdateInventorySource(id, updateInventorySourceData, nil)
 	if err != nil {
-		return buildDiagUpdateFail(diagElementInventorySourceTitle, id, err)
+		return buildDiagUpdateFail(diagElementInventorySourceTitle, id, err, d)
 	}
 
 	return resourceInventorySourceRead(ctx, d, m)
@@ -213,7 +211,7 @@ func resourceInventorySourceDelete(ctx context.Context, d *schema.ResourceData,
 	if diags.HasError() {
 		return diags
 	}
-	if _, err := awxService.DeleteInventorySource(id); err != nil {
+	if _, err := awxService.DeleteInventorySource(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			"inventroy source",
 			fmt.Sprintf("inventroy source %v, got %s ",
@@ -232,7 +230,7 @@ func resourceInventorySourceRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	res, err := awxService.GetInventorySourceByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail(diagElementInventorySourceTitle, id, err)
+		return buildDiagReadFail(diagElementInventorySourceTitle, id, err, d)
 	}
 	d = setInventorySourceResourceData(d, res)
 	return nil
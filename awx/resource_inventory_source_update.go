@@ -0,0 +1,216 @@
+/*
+Trigger a sync of an inventory source, for example after changing cloud credentials, without recreating the source.
+The sync is launched again whenever one of the triggers changes.
+
+Example Usage
+
+```hcl
+resource "awx_inventory_source_update" "sync" {
+  inventory_source_id = awx_inventory_source.aws.id
+  wait_for_completion = true
+
+  triggers = {
+    credential = awx_credential.aws.id
+  }
+}
+```
+
+*/
+
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceInventorySourceUpdateLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceInventorySourceUpdateLaunchCreate,
+		ReadContext:   resourceInventorySourceUpdateLaunchRead,
+		UpdateContext: resourceInventorySourceUpdateLaunchUpdate,
+		DeleteContext: resourceInventorySourceUpdateLaunchDelete,
+
+		Schema: map[string]*schema.Schema{
+			"inventory_source_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Inventory Source ID",
+				ForceNew:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, will sync the inventory source again.",
+				ForceNew:    true,
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Resource creation will wait for the inventory update completion.",
+				ForceNew:    true,
+			},
+			"cancel_on_interrupt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Cancel the inventory update when Terraform is interrupted or the create timeout expires while waiting for its completion.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the inventory update.",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the inventory update failed.",
+			},
+			"source_hosts_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current number of hosts of the inventory source, read again on every refresh rather than recorded by this update.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+const (
+	inventorySourceUpdateLaunchAPIEndpoint = "/api/v2/inventory_sources/%d/update/"
+	inventorySourceHostsAPIEndpoint        = "/api/v2/inventory_sources/%d/hosts/"
+	inventoryUpdateAPIEndpoint             = "/api/v2/inventory_updates/%d/"
+)
+
+// inventoryUpdateLaunch is the response of the inventory source update endpoint.
+type inventoryUpdateLaunch struct {
+	ID              int `json:"id"`
+	InventoryUpdate int `json:"inventory_update"`
+}
+
+// inventoryUpdateOutcome holds the fields of an inventory update exposed by awx_inventory_source_update.
+type inventoryUpdateOutcome struct {
+	jobOutcome
+	JobExplanation  string `json:"job_explanation"`
+	InventorySource int    `json:"inventory_source"`
+}
+
+func statusInventoryUpdateState(ctx context.Context, client *apiClient, id int) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output := new(inventoryUpdateOutcome)
+		if err := client.getJSON(fmt.Sprintf(inventoryUpdateAPIEndpoint, id), output, map[string]string{}); err != nil {
+			return nil, "", err
+		}
+		return output, output.Status, nil
+	}
+}
+
+func resourceInventorySourceUpdateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*awx.AWX)
+	api := apiClientFor(m)
+
+	inventorySourceID := d.Get("inventory_source_id").(int)
+	if _, err := client.InventorySourcesService.GetInventorySourceByID(inventorySourceID, make(map[string]string)); err != nil {
+		return buildDiagNotFoundFail(diagElementInventorySourceTitle, inventorySourceID, err)
+	}
+
+	res := new(inventoryUpdateLaunch)
+	if err := api.postJSON(fmt.Sprintf(inventorySourceUpdateLaunchAPIEndpoint, inventorySourceID), map[string]interface{}{}, res); err != nil {
+		log.Printf("Failed to update Inventory Source %v", err)
+		return buildDiagnosticsMessage(
+			"Unable to update Inventory Source",
+			"InventoryUpdate with inventory source ID %d, failed to create %s", inventorySourceID, err.Error(),
+		)
+	}
+	updateID := res.InventoryUpdate
+	if updateID == 0 {
+		updateID = res.ID
+	}
+	d.SetId(strconv.Itoa(updateID))
+
+	if d.Get("wait_for_completion").(bool) {
+		stateConf := &retry.StateChangeConf{
+			Pending:    jobPendingStatuses,
+			Target:     jobFinalStatuses,
+			Refresh:    statusInventoryUpdateState(ctx, api, updateID),
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Delay:      2 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		result, err := stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			if isWaitInterrupted(ctx, err) && d.Get("cancel_on_interrupt").(bool) {
+				if err := api.postJSON(fmt.Sprintf(inventoryUpdateAPIEndpoint, updateID)+"cancel/", map[string]interface{}{}, nil); err != nil {
+					log.Printf("Failed to cancel InventoryUpdate %d %v", updateID, err)
+				}
+			}
+			diags = append(diags, buildDiagnosticsMessage(
+				"InventoryUpdate execution failure",
+				"InventoryUpdate with ID %d and inventory source ID %d, failed to complete %s", updateID, inventorySourceID, err.Error(),
+			)...)
+		} else if update := result.(*inventoryUpdateOutcome); update.Status != awx.JobStatusSuccessful {
+			diags = append(diags, buildDiagJobFailed(
+				"InventoryUpdate",
+				updateID,
+				update.Status,
+				api.uiURL("/jobs/inventory/%d/output", updateID),
+				update.JobExplanation,
+				jobStdoutTail(api, fmt.Sprintf(inventoryUpdateAPIEndpoint, updateID)),
+			)...)
+		}
+	}
+	return append(diags, resourceInventorySourceUpdateLaunchRead(ctx, d, m)...)
+}
+
+func resourceInventorySourceUpdateLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	api := apiClientFor(m)
+	id, diags := convertStateIDToNummeric("Read InventoryUpdate", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	update := new(inventoryUpdateOutcome)
+	if err := api.getJSON(fmt.Sprintf(inventoryUpdateAPIEndpoint, id), update, map[string]string{}); err != nil {
+		if isNotFound(err) {
+			// AWX purges old updates, the update itself still happened so keep the last known state.
+			log.Printf("Inventory update %d not found, keeping its last known outcome", id)
+			return diags
+		}
+		return buildDiagNotFoundFail("inventory update", id, err)
+	}
+
+	hosts := new(awx.Pagination)
+	if err := api.getJSON(fmt.Sprintf(inventorySourceHostsAPIEndpoint, d.Get("inventory_source_id").(int)), hosts, map[string]string{"page_size": "1"}); err != nil {
+		return buildDiagNotFoundFail("inventory source hosts", d.Get("inventory_source_id").(int), err)
+	}
+
+	d.Set("status", update.Status)
+	d.Set("failed", update.Failed)
+	d.Set("source_hosts_count", hosts.Count)
+	return diags
+}
+
+// resourceInventorySourceUpdateLaunchUpdate only stores the new cancel_on_interrupt, every other argument forces a new sync.
+func resourceInventorySourceUpdateLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceInventorySourceUpdateLaunchRead(ctx, d, m)
+}
+
+func resourceInventorySourceUpdateLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
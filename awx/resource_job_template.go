@@ -30,6 +30,7 @@ import (
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -39,6 +40,10 @@ func resourceJobTemplate() *schema.Resource {
 		ReadContext:   resourceJobTemplateRead,
 		UpdateContext: resourceJobTemplateUpdate,
 		DeleteContext: resourceJobTemplateDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffRequires(featureExecutionEnvironments, "execution_environment"),
+			customizeDiffRequires(featurePreventInstanceGroupFallback, "prevent_instance_group_fallback"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -201,16 +206,22 @@ func resourceJobTemplate() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
+			"prevent_instance_group_fallback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Run the jobs only on the instance groups of the job template, without falling back to those of the inventory and organization. Left as is in AWX when not set",
+			},
 		},
+		Importer: importStateNaturalKey(jobTemplateKey),
 	}
 }
 
 func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.JobTemplateService
 
-	result, err := awxService.CreateJobTemplate(map[string]interface{}{
+	payload := map[string]interface{}{
 		"name":                     d.Get("name").(string),
 		"description":              d.Get("description").(string),
 		"job_type":                 d.Get("job_type").(string),
@@ -243,15 +254,21 @@ func resourceJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m in
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
 		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
 		"execution_environment":    AtoipOr(d.Get("execution_environment").(string), nil),
-	}, map[string]string{})
+	}
+	// Only sent when configured, so that servers predating it never get it and
+	// a value set outside of Terraform is kept otherwise.
+	if isConfigured(d, "prevent_instance_group_fallback") {
+		payload["prevent_instance_group_fallback"] = d.Get("prevent_instance_group_fallback").(bool)
+	}
+
+	result, err := awxService.CreateJobTemplate(payload, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d, failed to create %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to create JobTemplate",
+			fmt.Sprintf("JobTemplate with name %s in the project id %d, failed to create", d.Get("name").(string), d.Get("project_id").(int)),
+			err, d,
+		)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -270,10 +287,10 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 	params := make(map[string]string)
 	_, err := awxService.GetJobTemplateByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("job template", id, err)
+		return buildDiagFetchFail("job template", id, err)
 	}
 
-	_, err = awxService.UpdateJobTemplate(id, map[string]interface{}{
+	payload := map[string]interface{}{
 		"name":                     d.Get("name").(string),
 		"description":              d.Get("description").(string),
 		"job_type":                 d.Get("job_type").(string),
@@ -306,14 +323,20 @@ func resourceJobTemplateUpdate(ctx context.Context, d *schema.ResourceData, m in
 		"allow_simultaneous":       d.Get("allow_simultaneous").(bool),
 		"custom_virtualenv":        AtoipOr(d.Get("custom_virtualenv").(string), nil),
 		"execution_environment":    AtoipOr(d.Get("execution_environment").(string), nil),
-	}, map[string]string{})
+	}
+	// Only sent when configured, so that servers predating it never get it and
+	// a value set outside of Terraform is kept otherwise.
+	if isConfigured(d, "prevent_instance_group_fallback") {
+		payload["prevent_instance_group_fallback"] = d.Get("prevent_instance_group_fallback").(bool)
+	}
+
+	_, err = awxService.UpdateJobTemplate(id, payload, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplate with name %s in the project id %d failed to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail(
+			"Unable to update JobTemplate",
+			fmt.Sprintf("JobTemplate with name %s in the project id %d failed to update", d.Get("name").(string), d.Get("project_id").(int)),
+			err, d,
+		)
 	}
 
 	return resourceJobTemplateRead(ctx, d, m)
@@ -330,16 +353,33 @@ func resourceJobTemplateRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	res, err := awxService.GetJobTemplateByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("job template", id, err)
+		return buildDiagReadFail("job template", id, err, d)
 
 	}
 	d = setJobTemplateResourceData(d, res)
+
+	// goawx decodes execution_environment as a string, while AWX returns its
+	// id, and does not know prevent_instance_group_fallback.
+	var raw struct {
+		ExecutionEnvironment         *int `json:"execution_environment"`
+		PreventInstanceGroupFallback bool `json:"prevent_instance_group_fallback"`
+	}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf("/api/v2/job_templates/%d/", id), &raw, map[string]string{}); err == nil {
+		d.Set("execution_environment", "")
+		if raw.ExecutionEnvironment != nil {
+			d.Set("execution_environment", strconv.Itoa(*raw.ExecutionEnvironment))
+		}
+		d.Set("prevent_instance_group_fallback", raw.PreventInstanceGroupFallback)
+	}
 	return nil
 }
 
 func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *schema.ResourceData {
 	d.Set("allow_simultaneous", r.AllowSimultaneous)
 	d.Set("ask_credential_on_launch", r.AskCredentialOnLaunch)
+	d.Set("ask_diff_mode_on_launch", r.AskDiffModeOnLaunch)
+	d.Set("ask_inventory_on_launch", r.AskInventoryOnLaunch)
+	d.Set("ask_verbosity_on_launch", r.AskVerbosityOnLaunch)
 	d.Set("ask_job_type_on_launch", r.AskJobTypeOnLaunch)
 	d.Set("ask_limit_on_launch", r.AskLimitOnLaunch)
 	d.Set("ask_skip_tags_on_launch", r.AskSkipTagsOnLaunch)
@@ -364,6 +404,7 @@ func setJobTemplateResourceData(d *schema.ResourceData, r *awx.JobTemplate) *sch
 	d.Set("skip_tags", r.SkipTags)
 	d.Set("start_at_task", r.StartAtTask)
 	d.Set("survey_enabled", r.SurveyEnabled)
+	d.Set("timeout", r.Timeout)
 	d.Set("verbosity", r.Verbosity)
 	d.SetId(strconv.Itoa(r.ID))
 	return d
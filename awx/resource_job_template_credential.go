@@ -42,6 +42,7 @@ func resourceJobTemplateCredentials() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("job_template_id", jobTemplateKey, "credential_id", credentialKey),
 	}
 }
 
@@ -68,8 +69,7 @@ func resourceJobTemplateCredentialsCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceJobTemplateCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	return readAssociation("JobTemplate credential", "/api/v2/job_templates/%d/credentials/", "job_template_id", "credential_id")(ctx, d, m)
 }
 
 func resourceJobTemplateCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -78,6 +78,10 @@ func resourceJobTemplateCredentialsDelete(ctx context.Context, d *schema.Resourc
 	awxService := client.JobTemplateService
 	jobTemplateID := d.Get("job_template_id").(int)
 	res, err := awxService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return buildDiagNotFoundFail("job template", jobTemplateID, err)
 	}
@@ -85,7 +89,7 @@ func resourceJobTemplateCredentialsDelete(ctx context.Context, d *schema.Resourc
 	_, err = awxService.DisAssociateCredentials(res.ID, map[string]interface{}{
 		"id": d.Get("credential_id").(int),
 	}, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail("JobTemplate DisAssociateCredentials", fmt.Sprintf("DisAssociateCredentials %v, from JobTemplateID %v got %s ", d.Get("credential_id").(int), d.Get("job_template_id").(int), err.Error()))
 	}
 
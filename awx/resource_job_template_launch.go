@@ -19,7 +19,27 @@ resource "awx_job_template" "baseconfig" {
 }
 
 resource "awx_job_template_launch" "now" {
-  job_template_id = awx_job_template.baseconfig.id
+  job_template_id     = awx_job_template.baseconfig.id
+  wait_for_completion = true
+}
+
+output "generated_hostname" {
+  value = jsondecode(awx_job_template_launch.now.artifacts)["hostname"]
+}
+
+resource "awx_job_template_launch" "provision" {
+  job_template_id     = awx_job_template.baseconfig.id
+  limit               = "myhost"
+  wait_for_completion = true
+
+  triggers = {
+    playbook_version = var.playbook_version
+  }
+
+  on_destroy {
+    job_template_id = awx_job_template.decommission.id
+    limit           = "myhost"
+  }
 }
 ```
 
@@ -30,13 +50,20 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -45,20 +72,25 @@ func resourceJobTemplateLaunch() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateLaunchCreate,
 		ReadContext:   resourceJobRead,
+		UpdateContext: resourceJobUpdate,
 		DeleteContext: resourceJobDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffRequires(featureLaunchPrompts, "execution_environment_id", "label_ids", "instance_group_ids", "forks", "timeout", "job_slice_count"),
+			customizeDiffLaunch("JobTemplate", "job_template_id", jobTemplateLaunchAPIEndpoint, jobTemplateLaunchPrompts, jobRelaunchArguments),
+			customizeDiffRelaunch,
+			customizeDiffStdoutFile,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
 				Type:        schema.TypeInt,
 				Required:    true,
 				Description: "Job template ID",
-				ForceNew:    true,
 			},
 			"limit": {
 				Type:        schema.TypeString,
 				Required:    false,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "List of comma delimited hosts to limit job execution. Required ask_limit_on_launch set on job_template.",
 			},
 			"inventory_id": {
@@ -67,61 +99,555 @@ func resourceJobTemplateLaunch() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 				Description: "Override Inventory ID. Required ask_inventory_on_launch set on job_template.",
-				ForceNew:    true,
 			},
 			"extra_vars": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Override job template variables. YAML or JSON values are supported.",
-				ForceNew:    true,
 				StateFunc:   normalizeJsonYaml,
 			},
+			"job_tags": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma delimited list of tags to run. Required ask_tags_on_launch set on job_template.",
+			},
+			"skip_tags": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma delimited list of tags to skip. Required ask_skip_tags_on_launch set on job_template.",
+			},
+			"verbosity": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override verbosity, from 0 (normal) to 5 (WinRM debug). Required ask_verbosity_on_launch set on job_template.",
+			},
+			"job_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Override job type, one of run or check. Required ask_job_type_on_launch set on job_template.",
+			},
+			"diff_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Override diff mode. Required ask_diff_mode_on_launch set on job_template.",
+			},
+			"credential_ids": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Override credential IDs. Required ask_credential_on_launch set on job_template.",
+			},
+			"scm_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Override project branch. Required ask_scm_branch_on_launch set on job_template.",
+			},
+			"execution_environment_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override Execution Environment ID. Required ask_execution_environment_on_launch set on job_template.",
+			},
+			"label_ids": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Override label IDs. Required ask_labels_on_launch set on job_template.",
+			},
+			"instance_group_ids": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Override Instance Group IDs, in order of preference. Required ask_instance_groups_on_launch set on job_template.",
+			},
+			"forks": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override number of forks. Required ask_forks_on_launch set on job_template.",
+			},
+			"timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override job timeout in seconds. Required ask_timeout_on_launch set on job_template.",
+			},
+			"job_slice_count": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override number of job slices. Required ask_job_slice_count_on_launch set on job_template.",
+			},
+			"passwords": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passwords required by the job credentials to start, keyed by name such as ssh_password, become_password or vault_password.",
+			},
 			"wait_for_completion": {
 				Type:        schema.TypeBool,
 				Required:    false,
 				Optional:    true,
 				Default:     false,
-				Description: "Resource creation will wait for job completion.",
-				ForceNew:    true,
+				Description: "Resource creation, and relaunches, will wait for job completion.",
+			},
+			"cancel_on_interrupt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Cancel the job when Terraform is interrupted or the create timeout expires while waiting for its completion.",
+			},
+			"cancel_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Cancel the job when the resource is destroyed, or the job relaunched, while it is still running.",
+			},
+			"delete_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the job record from AWX when the resource is destroyed or the job relaunched.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, will relaunch the job. The job is relaunched in place, on_destroy is not run.",
+			},
+			"on_destroy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Job template to launch, and wait for, when this resource is destroyed. It is not run when the job is relaunched because an argument or triggers changed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"job_template_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Job template ID",
+						},
+						"limit": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "List of comma delimited hosts to limit job execution. Required ask_limit_on_launch set on job_template.",
+						},
+						"inventory_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Override Inventory ID. Required ask_inventory_on_launch set on job_template.",
+						},
+						"extra_vars": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Override job template variables. YAML or JSON values are supported.",
+							StateFunc:   normalizeJsonYaml,
+						},
+					},
+				},
+			},
+			"capture_stdout": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Store the job standard output in the stdout attribute.",
+			},
+			"stdout_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path of a local file the full job standard output is written to once the job completes. Requires wait_for_completion.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the job: new, pending, waiting, running, successful, failed, error or canceled.",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the job failed.",
+			},
+			"started": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the job started.",
+			},
+			"finished": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the job finished.",
+			},
+			"elapsed": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Elapsed time of the job in seconds.",
+			},
+			"artifacts": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON encoded artifacts produced by the set_stats module.",
+			},
+			"stdout": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Standard output of the job, only set when capture_stdout is enabled.",
 			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
 
-func statusInstanceState(ctx context.Context, svc *awx.JobService, id int) retry.StateRefreshFunc {
+// jobPendingStatuses and jobFinalStatuses list the states of AWX unified jobs.
+var (
+	jobPendingStatuses = []string{awx.JobStatusNew, awx.JobStatusPending, awx.JobStatusWaiting, awx.JobStatusRunning}
+	jobFinalStatuses   = []string{awx.JobStatusSuccessful, awx.JobStatusFailed, awx.JobStatusError, awx.JobStatusCanceled}
+)
+
+// jobStdoutTailLines is the number of stdout lines reported when a job does not succeed.
+const jobStdoutTailLines = 20
+
+func statusInstanceState(ctx context.Context, svc *awx.JobService, id int, output *jobOutputStreamer) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		output, err := svc.GetJob(id, map[string]string{})
-		return output, output.Status, err
+		if output != nil {
+			output.stream(ctx)
+		}
+		result, err := svc.GetJob(id, map[string]string{})
+		if err != nil {
+			return nil, "", err
+		}
+		return result, result.Status, nil
 	}
 }
 
-func jobTemplateLaunchWait(ctx context.Context, svc *awx.JobService, job *awx.JobLaunch, timeout time.Duration) error {
+// jobTemplateLaunchWait waits for a job to finish, and forwards its output to
+// the Terraform logs while it is running when stream is set.
+func jobTemplateLaunchWait(ctx context.Context, svc *awx.JobService, id int, timeout time.Duration, stream bool) (*awx.Job, error) {
+	var output *jobOutputStreamer
+	if stream {
+		output = &jobOutputStreamer{svc: svc, id: id}
+		defer output.stream(ctx)
+	}
 
 	stateConf := &retry.StateChangeConf{
-		Pending:    []string{"new", "pending", "waiting", "running"},
-		Target:     []string{"successful"},
-		Refresh:    statusInstanceState(ctx, svc, job.ID),
+		Pending:    jobPendingStatuses,
+		Target:     jobFinalStatuses,
+		Refresh:    statusInstanceState(ctx, svc, id, output),
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*awx.Job), nil
+}
+
+// ansiEscapeSequence matches the color codes of the job events output.
+var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// jobOutputStreamer forwards the output of a running job to the Terraform logs
+// at INFO level, paging through the job events it has not seen yet.
+type jobOutputStreamer struct {
+	svc     *awx.JobService
+	id      int
+	counter int
+}
+
+func (s *jobOutputStreamer) stream(ctx context.Context) {
+	for {
+		events, res, err := s.svc.GetJobEvents(s.id, map[string]string{
+			"counter__gt": strconv.Itoa(s.counter),
+			"order_by":    "counter",
+			"page_size":   "200",
+		})
+		if err != nil {
+			tflog.Warn(ctx, "Unable to fetch job events", map[string]interface{}{"job_id": s.id, "error": err.Error()})
+			return
+		}
+		for _, event := range events {
+			s.counter = event.Counter
+			for _, line := range strings.Split(ansiEscapeSequence.ReplaceAllString(event.Stdout, ""), "\n") {
+				if strings.TrimSpace(line) != "" {
+					tflog.Info(ctx, line, map[string]interface{}{"job_id": s.id})
+				}
+			}
+		}
+		if len(events) == 0 || res.Next == nil {
+			return
+		}
+	}
+}
+
+// writeJobStdout writes the full standard output of a job to path.
+func writeJobStdout(client *apiClient, id int, path string) error {
+	stdout, err := client.getText(fmt.Sprintf(jobAPIEndpoint, id)+"stdout/", map[string]string{"format": "txt"})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(stdout), 0644)
+}
+
+// isJobPending reports whether a job with the given status has not finished yet.
+func isJobPending(status string) bool {
+	for _, pending := range jobPendingStatuses {
+		if status == pending {
+			return true
+		}
+	}
+	return false
+}
+
+// isWaitInterrupted reports whether waiting for a job stopped because
+// Terraform was interrupted or the timeout expired.
+func isWaitInterrupted(ctx context.Context, err error) bool {
+	var timeoutErr *retry.TimeoutError
+	return ctx.Err() != nil || errors.As(err, &timeoutErr)
+}
+
+// jobStdoutTail returns the last lines of the stdout of the unified job at
+// endpoint, or an empty string when it can't be fetched.
+func jobStdoutTail(client *apiClient, endpoint string) string {
+	stdout, err := client.getText(endpoint+"stdout/", map[string]string{"format": "txt"})
+	if err != nil {
+		log.Printf("Unable to fetch stdout of %s, %v", endpoint, err)
+		return ""
+	}
+	lines := strings.Split(strings.TrimRight(stdout, "\n"), "\n")
+	if len(lines) > jobStdoutTailLines {
+		lines = lines[len(lines)-jobStdoutTailLines:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jobOutcome holds the fields of a job exposed by the launch resources. It is
+// decoded by hand because goawx expects artifacts to only contain strings.
+type jobOutcome struct {
+	Status    string                 `json:"status"`
+	Failed    bool                   `json:"failed"`
+	Started   string                 `json:"started"`
+	Finished  string                 `json:"finished"`
+	Elapsed   float64                `json:"elapsed"`
+	Artifacts map[string]interface{} `json:"artifacts"`
+}
+
+const (
+	jobAPIEndpoint               = "/api/v2/jobs/%d/"
+	jobTemplateLaunchAPIEndpoint = "/api/v2/job_templates/%d/launch/"
+)
+
+// jobTemplateLaunchPrompts maps the launch attributes to the job template
+// setting allowing them to be overridden at launch.
+var jobTemplateLaunchPrompts = map[string]string{
+	"limit":                    "ask_limit_on_launch",
+	"inventory_id":             "ask_inventory_on_launch",
+	"job_tags":                 "ask_tags_on_launch",
+	"skip_tags":                "ask_skip_tags_on_launch",
+	"verbosity":                "ask_verbosity_on_launch",
+	"job_type":                 "ask_job_type_on_launch",
+	"diff_mode":                "ask_diff_mode_on_launch",
+	"credential_ids":           "ask_credential_on_launch",
+	"scm_branch":               "ask_scm_branch_on_launch",
+	"execution_environment_id": "ask_execution_environment_on_launch",
+	"label_ids":                "ask_labels_on_launch",
+	"instance_group_ids":       "ask_instance_groups_on_launch",
+	"forks":                    "ask_forks_on_launch",
+	"timeout":                  "ask_timeout_on_launch",
+	"job_slice_count":          "ask_job_slice_count_on_launch",
+}
+
+// jobRelaunchArguments are the arguments relaunching the job when they change.
+// The job is relaunched in place rather than replaced, so that on_destroy only
+// runs when the resource is really destroyed.
+var jobRelaunchArguments = []string{
+	"job_template_id", "limit", "inventory_id", "extra_vars", "job_tags", "skip_tags", "verbosity",
+	"job_type", "diff_mode", "credential_ids", "scm_branch", "execution_environment_id", "label_ids",
+	"instance_group_ids", "forks", "timeout", "job_slice_count", "passwords", "triggers",
+}
+
+// jobOutcomeAttributes are the attributes read from the launched job.
+var jobOutcomeAttributes = []string{"status", "failed", "started", "finished", "elapsed", "artifacts", "stdout"}
+
+// customizeDiffRelaunch plans the outcome of the job launched again by a
+// change of its arguments as unknown.
+func customizeDiffRelaunch(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if !d.HasChanges(jobRelaunchArguments...) {
+		// The stdout of the current job is only read again.
+		if d.HasChange("capture_stdout") {
+			return d.SetNewComputed("stdout")
+		}
+		return nil
+	}
+	for _, attribute := range jobOutcomeAttributes {
+		if err := d.SetNewComputed(attribute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customizeDiffStdoutFile fails the plan when stdout_file is set without
+// wait_for_completion, as the stdout is only written once the job completed.
+func customizeDiffStdoutFile(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("stdout_file").(string) != "" && !d.Get("wait_for_completion").(bool) {
+		return fmt.Errorf("stdout_file requires wait_for_completion")
+	}
+	return nil
+}
 
-	return err
+// launchConfigReader is implemented by both schema.ResourceData and schema.ResourceDiff.
+type launchConfigReader interface {
+	rawConfigReader
+	Get(key string) interface{}
+}
+
+// checkLaunchRequirements returns the reasons why AWX would reject, or silently
+// alter, a launch configured with d, according to the template launch requirements.
+func checkLaunchRequirements(tfElement string, id int, launch map[string]interface{}, prompts map[string]string, d launchConfigReader) []string {
+	var problems []string
+	attributes := make([]string, 0, len(prompts))
+	for attribute := range prompts {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		setting := prompts[attribute]
+		if allowed, _ := launch[setting].(bool); allowed || !isConfigured(d, attribute) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf(
+			"%s %d does not prompt for %s on launch, set %s on it or remove %s",
+			tfElement, id, attribute, setting, attribute,
+		))
+	}
+
+	askVariables, _ := launch["ask_variables_on_launch"].(bool)
+	surveyEnabled, _ := launch["survey_enabled"].(bool)
+	if isConfigured(d, "extra_vars") && !askVariables && !surveyEnabled {
+		problems = append(problems, fmt.Sprintf(
+			"%s %d does not prompt for extra_vars on launch and has no survey, set ask_variables_on_launch on it or remove extra_vars",
+			tfElement, id,
+		))
+	}
+
+	if needed, _ := launch["variables_needed_to_start"].([]interface{}); len(needed) > 0 && isKnown(d, "extra_vars") {
+		extraVars := unmarshalYaml(d.Get("extra_vars").(string))
+		for _, name := range needed {
+			if _, ok := extraVars[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf(
+					"%s %d survey requires an answer for %s, add it to extra_vars",
+					tfElement, id, name,
+				))
+			}
+		}
+	}
+
+	if needed, _ := launch["inventory_needed_to_start"].(bool); needed && !isConfigured(d, "inventory_id") {
+		problems = append(problems, fmt.Sprintf("%s %d has no inventory, %s", tfElement, id, launchFix(d, "inventory_id", "set an inventory on it")))
+	}
+
+	if needed, _ := launch["credential_needed_to_start"].(bool); needed && !isConfigured(d, "credential_ids") {
+		problems = append(problems, fmt.Sprintf("%s %d has no credential, %s", tfElement, id, launchFix(d, "credential_ids", "set a credential on it")))
+	}
+
+	if needed, _ := launch["passwords_needed_to_start"].([]interface{}); len(needed) > 0 && isKnown(d, "passwords") {
+		passwords, _ := d.Get("passwords").(map[string]interface{})
+		for _, name := range needed {
+			if _, ok := passwords[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf(
+					"%s %d credentials require %s to start, add it to passwords",
+					tfElement, id, name,
+				))
+			}
+		}
+	}
+
+	return problems
+}
+
+// launchFix tells how to provide a value missing at launch, through attribute
+// when the launch resource has it, or the template otherwise.
+func launchFix(d launchConfigReader, attribute, fallback string) string {
+	if config := d.GetRawConfig(); !config.IsNull() && config.Type().IsObjectType() && config.Type().HasAttribute(attribute) {
+		return "set " + attribute
+	}
+	return fallback
+}
+
+// customizeDiffLaunch fails the plan of a launch resource when the template
+// launch requirements, read from launchEndpoint, are not met. They are only
+// checked when the resource is created or one of launchArguments changes.
+func customizeDiffLaunch(tfElement, templateIDKey, launchEndpoint string, prompts map[string]string, launchArguments []string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" && !d.HasChanges(launchArguments...) {
+			return nil
+		}
+		if !d.NewValueKnown(templateIDKey) {
+			return nil
+		}
+
+		api := apiClientFor(m)
+		if api == nil {
+			return nil
+		}
+
+		id := d.Get(templateIDKey).(int)
+		launch := map[string]interface{}{}
+		if err := api.getJSON(fmt.Sprintf(launchEndpoint, id), &launch, map[string]string{}); err != nil {
+			return fmt.Errorf("unable to fetch launch requirements of %s %d: %s", tfElement, id, err.Error())
+		}
+
+		if problems := checkLaunchRequirements(tfElement, id, launch, prompts, d); len(problems) > 0 {
+			return fmt.Errorf("%s", strings.Join(problems, "\n"))
+		}
+		return nil
+	}
 }
 
 // JobTemplateLaunchData provides payload data used by the JobTemplateLaunch method
 type JobTemplateLaunchData struct {
-	Limit       string `json:"limit,omitempty"`
-	InventoryID int    `json:"inventory,omitempty"`
-	ExtraVars   string `json:"extra_vars,omitempty"`
+	Limit                  string `json:"limit,omitempty"`
+	InventoryID            int    `json:"inventory,omitempty"`
+	ExtraVars              string `json:"extra_vars,omitempty"`
+	JobTags                string `json:"job_tags,omitempty"`
+	SkipTags               string `json:"skip_tags,omitempty"`
+	Verbosity              *int   `json:"verbosity,omitempty"`
+	JobType                string `json:"job_type,omitempty"`
+	DiffMode               *bool  `json:"diff_mode,omitempty"`
+	CredentialIDs          []int  `json:"credentials,omitempty"`
+	ScmBranch              string `json:"scm_branch,omitempty"`
+	ExecutionEnvironmentID *int   `json:"execution_environment,omitempty"`
+	LabelIDs               []int  `json:"labels,omitempty"`
+	InstanceGroupIDs       []int  `json:"instance_groups,omitempty"`
+	Forks                  *int   `json:"forks,omitempty"`
+	Timeout                *int   `json:"timeout,omitempty"`
+	JobSliceCount          *int   `json:"job_slice_count,omitempty"`
+}
+
+// configuredInt returns the value of an integer attribute, or nil when it is
+// not configured so that an explicit 0 is still sent to AWX.
+func configuredInt(d launchConfigReader, key string) *int {
+	if !isConfigured(d, key) {
+		return nil
+	}
+	value := d.Get(key).(int)
+	return &value
 }
 
 func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return jobTemplateLaunch(ctx, d, m, d.Timeout(schema.TimeoutCreate))
+}
+
+// jobTemplateLaunch launches the job template of d, waiting up to timeout for
+// the job to complete when wait_for_completion is set.
+func jobTemplateLaunch(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.JobTemplateService
@@ -133,58 +659,260 @@ func resourceJobTemplateLaunchCreate(ctx context.Context, d *schema.ResourceData
 		return buildDiagNotFoundFail("job template", jobTemplateID, err)
 	}
 
+	launch := map[string]interface{}{}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf(jobTemplateLaunchAPIEndpoint, jobTemplateID), &launch, map[string]string{}); err != nil {
+		return buildDiagNotFoundFail("job template launch requirements", jobTemplateID, err)
+	}
+	if problems := checkLaunchRequirements("JobTemplate", jobTemplateID, launch, jobTemplateLaunchPrompts, d); len(problems) > 0 {
+		for _, problem := range problems {
+			diags = append(diags, buildDiagnosticsMessage("JobTemplate launch requirements not met", "%s", problem)...)
+		}
+		return diags
+	}
+
 	data := JobTemplateLaunchData{
-		Limit:       d.Get("limit").(string),
-		InventoryID: d.Get("inventory_id").(int),
-		ExtraVars:   d.Get("extra_vars").(string),
+		Limit:                  d.Get("limit").(string),
+		InventoryID:            d.Get("inventory_id").(int),
+		ExtraVars:              d.Get("extra_vars").(string),
+		JobTags:                d.Get("job_tags").(string),
+		SkipTags:               d.Get("skip_tags").(string),
+		JobType:                d.Get("job_type").(string),
+		CredentialIDs:          toIntSlice(d.Get("credential_ids").([]interface{})),
+		ScmBranch:              d.Get("scm_branch").(string),
+		ExecutionEnvironmentID: configuredInt(d, "execution_environment_id"),
+		LabelIDs:               toIntSlice(d.Get("label_ids").([]interface{})),
+		InstanceGroupIDs:       toIntSlice(d.Get("instance_group_ids").([]interface{})),
+		Verbosity:              configuredInt(d, "verbosity"),
+		Forks:                  configuredInt(d, "forks"),
+		Timeout:                configuredInt(d, "timeout"),
+		JobSliceCount:          configuredInt(d, "job_slice_count"),
+	}
+	if isConfigured(d, "diff_mode") {
+		diffMode := d.Get("diff_mode").(bool)
+		data.DiffMode = &diffMode
 	}
 
 	var iData map[string]interface{}
 	idata, _ := json.Marshal(data)
 	json.Unmarshal(idata, &iData)
+	for name, password := range d.Get("passwords").(map[string]interface{}) {
+		iData[name] = password
+	}
 
 	res, err := awxService.Launch(jobTemplateID, iData, map[string]string{})
 	if err != nil {
 		log.Printf("Failed to create Template Launch %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create JobTemplate",
-			Detail:   fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to create %s", d.Get("job_template_id").(int), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create JobTemplate",
+			fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to create", d.Get("job_template_id").(int)),
+			err, d,
+		)...)
 	}
 
 	// return resourceJobRead(ctx, d, m)
 	d.SetId(strconv.Itoa(res.ID))
 
 	if d.Get("wait_for_completion").(bool) {
-		err = jobTemplateLaunchWait(ctx, awxJobService, res, d.Timeout(schema.TimeoutCreate))
+		job, err := jobTemplateLaunchWait(ctx, awxJobService, res.ID, timeout, true)
 		if err != nil {
+			if isWaitInterrupted(ctx, err) && d.Get("cancel_on_interrupt").(bool) {
+				if _, err := awxJobService.CancelJob(res.ID, map[string]interface{}{}, map[string]string{}); err != nil {
+					log.Printf("Failed to cancel Job %d %v", res.ID, err)
+				}
+			}
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "JobTemplate execution failure",
 				Detail:   fmt.Sprintf("JobTemplateLaunch with ID %d and template ID %d, failed to complete %s", res.ID, d.Get("job_template_id").(int), err.Error()),
 			})
+		} else if job.Status != awx.JobStatusSuccessful {
+			api := apiClientFor(m)
+			diags = append(diags, buildDiagJobFailed(
+				"JobTemplate",
+				res.ID,
+				job.Status,
+				api.uiURL("/jobs/playbook/%d/output", res.ID),
+				job.JobExplanation,
+				jobStdoutTail(api, fmt.Sprintf(jobAPIEndpoint, res.ID)),
+			)...)
+		}
+
+		if path := d.Get("stdout_file").(string); path != "" {
+			if err := writeJobStdout(apiClientFor(m), res.ID, path); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Unable to write job stdout",
+					Detail:   fmt.Sprintf("Stdout of job %d could not be written to %s: %s", res.ID, path, err.Error()),
+				})
+			}
 		}
 	}
-	return diags
+	return append(diags, resourceJobRead(ctx, d, m)...)
 }
 
 func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	client := apiClientFor(m)
+	jobID, diags := convertStateIDToNummeric("Read Job", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	endpoint := fmt.Sprintf(jobAPIEndpoint, jobID)
+	job := new(jobOutcome)
+	if err := client.getJSON(endpoint, job, map[string]string{}); err != nil {
+		if isNotFound(err) {
+			// AWX purges old jobs, the launch itself still happened so keep the last known state.
+			log.Printf("Job %d not found, keeping its last known outcome", jobID)
+			return diags
+		}
+		return buildDiagNotFoundFail("job", jobID, err)
+	}
+
+	artifacts := ""
+	if len(job.Artifacts) > 0 {
+		b, err := json.Marshal(job.Artifacts)
+		if err != nil {
+			return buildDiagnosticsMessage("Unable to encode job artifacts", "Artifacts of job %d can't be encoded, got %s", jobID, err.Error())
+		}
+		artifacts = string(b)
+	}
+
+	d.Set("status", job.Status)
+	d.Set("failed", job.Failed)
+	d.Set("started", job.Started)
+	d.Set("finished", job.Finished)
+	d.Set("elapsed", job.Elapsed)
+	d.Set("artifacts", artifacts)
+
+	if d.Get("capture_stdout").(bool) {
+		stdout, err := client.getText(endpoint+"stdout/", map[string]string{"format": "txt"})
+		if err != nil {
+			return buildDiagnosticsMessage("Unable to fetch job stdout", "Unable to load stdout of job %d: got %s", jobID, err.Error())
+		}
+		d.Set("stdout", stdout)
+	} else {
+		d.Set("stdout", "")
+	}
 	return diags
 }
 
-func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// resourceJobUpdate relaunches the job when one of its arguments changed,
+// releasing the previous job as a destroy would without running on_destroy.
+// Otherwise it only stores the new on_destroy, lifecycle and output settings.
+func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChanges(jobRelaunchArguments...) {
+		return resourceJobRead(ctx, d, m)
+	}
+
+	jobID, diags := convertStateIDToNummeric("Update Job", d)
+	if diags.HasError() {
+		return diags
+	}
+	if diags := jobRelease(ctx, d, m, jobID); diags.HasError() {
+		return diags
+	}
+	diags = jobTemplateLaunch(ctx, d, m, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		// Keep the previous arguments so that the next apply launches the job again.
+		d.Partial(true)
+	}
+	return diags
+}
+
+// jobLaunchOnDestroy launches the on_destroy job template and waits for its completion.
+func jobLaunchOnDestroy(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
-	awxService := client.JobService
+	onDestroy := d.Get("on_destroy").([]interface{})
+	if len(onDestroy) == 0 || onDestroy[0] == nil {
+		return diags
+	}
+	config := onDestroy[0].(map[string]interface{})
+	jobTemplateID := config["job_template_id"].(int)
+
+	data := JobTemplateLaunchData{
+		Limit:       config["limit"].(string),
+		InventoryID: config["inventory_id"].(int),
+		ExtraVars:   config["extra_vars"].(string),
+	}
+
+	var iData map[string]interface{}
+	idata, _ := json.Marshal(data)
+	json.Unmarshal(idata, &iData)
+
+	res, err := client.JobTemplateService.Launch(jobTemplateID, iData, map[string]string{})
+	if err != nil {
+		// The fields are those of on_destroy, not of the top-level attributes.
+		return buildDiagAPIFail(
+			"Unable to launch on_destroy JobTemplate",
+			fmt.Sprintf("JobTemplateLaunch with template ID %d, failed to create", jobTemplateID),
+			err, nil,
+		)
+	}
+
+	job, err := jobTemplateLaunchWait(ctx, client.JobService, res.ID, d.Timeout(schema.TimeoutDelete), true)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"on_destroy JobTemplate execution failure",
+			"JobTemplateLaunch with ID %d and template ID %d, failed to complete %s", res.ID, jobTemplateID, err.Error(),
+		)
+	}
+	if job.Status != awx.JobStatusSuccessful {
+		api := apiClientFor(m)
+		return buildDiagJobFailed(
+			"on_destroy JobTemplate",
+			res.ID,
+			job.Status,
+			api.uiURL("/jobs/playbook/%d/output", res.ID),
+			job.JobExplanation,
+			jobStdoutTail(api, fmt.Sprintf(jobAPIEndpoint, res.ID)),
+		)
+	}
+	return diags
+}
+
+func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	jobID, diags := convertStateIDToNummeric("Delete Job", d)
-	_, err := awxService.GetJob(jobID, map[string]string{})
+	if diags.HasError() {
+		return diags
+	}
+	if diags := jobLaunchOnDestroy(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	if diags := jobRelease(ctx, d, m, jobID); diags.HasError() {
+		return diags
+	}
+	d.SetId("")
+	return diags
+}
+
+// jobRelease cancels and deletes the job as set by cancel_on_destroy and
+// delete_on_destroy.
+func jobRelease(ctx context.Context, d *schema.ResourceData, m interface{}, jobID int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	awxService := m.(*awx.AWX).JobService
+	job, err := awxService.GetJob(jobID, map[string]string{})
+	if isNotFound(err) {
+		return diags
+	}
 	if err != nil {
 		return buildDiagNotFoundFail("job", jobID, err)
 	}
 
-	d.SetId("")
+	if d.Get("cancel_on_destroy").(bool) && isJobPending(job.Status) {
+		if _, err := awxService.CancelJob(jobID, map[string]interface{}{}, map[string]string{}); err != nil {
+			return buildDiagDeleteFail("Job", fmt.Sprintf("unable to cancel JobID %v, got %s ", jobID, err.Error()))
+		}
+		if _, err := jobTemplateLaunchWait(ctx, awxService, jobID, d.Timeout(schema.TimeoutDelete), false); err != nil {
+			return buildDiagDeleteFail("Job", fmt.Sprintf("JobID %v not canceled, got %s ", jobID, err.Error()))
+		}
+	}
+
+	if d.Get("delete_on_destroy").(bool) {
+		if err := apiClientFor(m).delete(fmt.Sprintf(jobAPIEndpoint, jobID)); err != nil && !isNotFound(err) {
+			return buildDiagDeleteFail("Job", fmt.Sprintf("JobID %v, got %s ", jobID, err.Error()))
+		}
+	}
 	return diags
 }
@@ -21,7 +21,7 @@ func resourceJobTemplateNotificationTemplateError() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateNotificationTemplateCreateForType("error"),
 		DeleteContext: resourceJobTemplateNotificationTemplateDeleteForType("error"),
-		ReadContext:   resourceJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceJobTemplateNotificationTemplateReadForType("error"),
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
@@ -35,5 +35,6 @@ func resourceJobTemplateNotificationTemplateError() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("job_template_id", jobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
@@ -2,6 +2,7 @@ package awx
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	awx "github.com/denouche/goawx/client"
@@ -61,9 +62,12 @@ func resourceJobTemplateNotificationTemplateCreateForType(typ string) func(ctx c
 	}
 }
 
-func resourceJobTemplateNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+func resourceJobTemplateNotificationTemplateReadForType(typ string) schema.ReadContextFunc {
+	return readAssociation(
+		fmt.Sprintf("JobTemplate notification_template %s", typ),
+		"/api/v2/job_templates/%d/notification_templates_"+typ+"/",
+		"job_template_id", "notification_template_id",
+	)
 }
 
 func resourceJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -73,6 +77,10 @@ func resourceJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx c
 		awxJobTemplateService := client.JobTemplateService
 		jobTemplateID := d.Get("job_template_id").(int)
 		_, err := awxJobTemplateService.GetJobTemplateByID(jobTemplateID, make(map[string]string))
+		if isNotFound(err) {
+			d.SetId("")
+			return diags
+		}
 		if err != nil {
 			return buildDiagNotFoundFail("job template", jobTemplateID, err)
 		}
@@ -85,7 +93,7 @@ func resourceJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx c
 		}
 
 		_, err = disassociationFunc(jobTemplateID, notificationTemplateID)
-		if err != nil {
+		if err != nil && !isNotFound(err) {
 			return buildDiagnosticsMessage("Create: JobTemplate not DisassociateJobTemplateNotificationTemplates", "Fail to associate notification_template credentials with ID %v, for job_template ID %v, got error: %s", notificationTemplateID, jobTemplateID, err.Error())
 		}
 
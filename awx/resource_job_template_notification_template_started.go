@@ -21,7 +21,7 @@ func resourceJobTemplateNotificationTemplateStarted() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateNotificationTemplateCreateForType("started"),
 		DeleteContext: resourceJobTemplateNotificationTemplateDeleteForType("started"),
-		ReadContext:   resourceJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceJobTemplateNotificationTemplateReadForType("started"),
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
@@ -35,5 +35,6 @@ func resourceJobTemplateNotificationTemplateStarted() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("job_template_id", jobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
@@ -21,7 +21,7 @@ func resourceJobTemplateNotificationTemplateSuccess() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceJobTemplateNotificationTemplateCreateForType("success"),
 		DeleteContext: resourceJobTemplateNotificationTemplateDeleteForType("success"),
-		ReadContext:   resourceJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceJobTemplateNotificationTemplateReadForType("success"),
 
 		Schema: map[string]*schema.Schema{
 			"job_template_id": {
@@ -35,5 +35,6 @@ func resourceJobTemplateNotificationTemplateSuccess() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("job_template_id", jobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
@@ -49,11 +49,13 @@ func resourceNotificationTemplate() *schema.Resource {
 				Optional: true,
 			},
 			"notification_configuration": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:      schema.TypeString,
+				Optional:  true,
+				Default:   "",
+				StateFunc: normalizeJsonYaml,
 			},
 		},
+		Importer: importStateNaturalKey(notificationTemplateKey),
 	}
 }
 
@@ -83,12 +85,7 @@ func resourceNotificationTemplateCreate(ctx context.Context, d *schema.ResourceD
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create notification_template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create NotificationTemplate",
-			Detail:   fmt.Sprintf("NotificationTemplate failed to create %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create NotificationTemplate", "NotificationTemplate failed to create", err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -107,7 +104,7 @@ func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceD
 	params := make(map[string]string)
 	_, err := awxService.GetByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("notification_template", id, err)
+		return buildDiagFetchFail("notification_template", id, err)
 	}
 
 	notificationConfigurationStr := d.Get("notification_configuration").(string)
@@ -130,12 +127,7 @@ func resourceNotificationTemplateUpdate(ctx context.Context, d *schema.ResourceD
 		"notification_configuration": notificationConfigurationMap,
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update NotificationTemplate",
-			Detail:   fmt.Sprintf("notification_template with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update NotificationTemplate", fmt.Sprintf("notification_template with name %s failed to update", d.Get("name").(string)), err, d)
 	}
 
 	return resourceNotificationTemplateRead(ctx, d, m)
@@ -152,10 +144,31 @@ func resourceNotificationTemplateRead(ctx context.Context, d *schema.ResourceDat
 
 	res, err := awxService.GetByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("notification_template", id, err)
+		return buildDiagReadFail("notification_template", id, err, d)
 
 	}
 	d = setNotificationTemplateResourceData(d, res)
+
+	// goawx decodes the organization as a string, while AWX returns its id.
+	var raw struct {
+		Organization              int             `json:"organization"`
+		NotificationConfiguration json.RawMessage `json:"notification_configuration"`
+	}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf("/api/v2/notification_templates/%d/", id), &raw, map[string]string{}); err != nil {
+		return buildDiagReadFail("notification_template", id, err, d)
+	}
+	d.Set("organization_id", strconv.Itoa(raw.Organization))
+	// AWX returns the secrets of the configuration as $encrypted$, the
+	// configured ones are kept in their place.
+	var configuration, configured interface{}
+	if err := json.Unmarshal(raw.NotificationConfiguration, &configuration); err != nil {
+		return buildDiagnosticsMessage(
+			"Unable to parse notification_template",
+			"Unable to parse the notification_configuration of notification_template %d, got: %s", id, err.Error(),
+		)
+	}
+	json.Unmarshal([]byte(d.Get("notification_configuration").(string)), &configured)
+	d.Set("notification_configuration", settingValueString(restoreEncryptedValues(configuration, configured)))
 	return nil
 }
 
@@ -167,7 +180,7 @@ func resourceNotificationTemplateDelete(ctx context.Context, d *schema.ResourceD
 		return diags
 	}
 
-	if _, err := awxService.Delete(id); err != nil {
+	if _, err := awxService.Delete(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementHostTitle,
 			fmt.Sprintf("id %v, got %s ",
@@ -180,9 +193,7 @@ func resourceNotificationTemplateDelete(ctx context.Context, d *schema.ResourceD
 func setNotificationTemplateResourceData(d *schema.ResourceData, r *awx.NotificationTemplate) *schema.ResourceData {
 	d.Set("name", r.Name)
 	d.Set("description", r.Description)
-	d.Set("organization", r.Organization)
 	d.Set("notification_type", r.NotificationType)
-	d.Set("notification_configuration", r.NotificationConfiguration)
 	d.SetId(strconv.Itoa(r.ID))
 	return d
 }
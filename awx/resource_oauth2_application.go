@@ -0,0 +1,176 @@
+/*
+Manage an OAuth2 application, which tokens can be created for.
+
+Example Usage
+
+```hcl
+data "awx_organization" "default" {
+  name = "Default"
+}
+
+resource "awx_oauth2_application" "ci" {
+  name                     = "ci"
+  organization_id          = data.awx_organization.default.id
+  client_type              = "confidential"
+  authorization_grant_type = "password"
+}
+```
+
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceOAuth2Application() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOAuth2ApplicationCreate,
+		ReadContext:   resourceOAuth2ApplicationRead,
+		UpdateContext: resourceOAuth2ApplicationUpdate,
+		DeleteContext: resourceOAuth2ApplicationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"organization_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"client_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Whether the application can keep a secret: confidential or public",
+			},
+			"authorization_grant_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grant type the application uses to get tokens: authorization-code or password",
+			},
+			"redirect_uris": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "URIs allowed to receive the authorization code, required with the authorization-code grant type",
+			},
+			"skip_authorization": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the authorization step for completely trusted applications",
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret of a confidential application, only returned by AWX when the application is created",
+			},
+		},
+		Importer: importStateNaturalKey(applicationKey),
+	}
+}
+
+func oauth2ApplicationData(d *schema.ResourceData) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                     d.Get("name").(string),
+		"description":              d.Get("description").(string),
+		"organization":             d.Get("organization_id").(int),
+		"client_type":              d.Get("client_type").(string),
+		"authorization_grant_type": d.Get("authorization_grant_type").(string),
+		"redirect_uris":            strings.Join(toStringSlice(d.Get("redirect_uris").([]interface{})), " "),
+		"skip_authorization":       d.Get("skip_authorization").(bool),
+	}
+}
+
+func resourceOAuth2ApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.ApplicationService
+
+	result, err := awxService.CreateApplication(oauth2ApplicationData(d), map[string]string{})
+	if err != nil {
+		log.Printf("Fail to Create OAuth2 Application %v", err)
+		return buildDiagCreateFail("OAuth2 Application", err, d)
+	}
+
+	d.SetId(strconv.Itoa(result.ID))
+	d.Set("client_secret", result.ClientSecret)
+	return resourceOAuth2ApplicationRead(ctx, d, m)
+}
+
+func resourceOAuth2ApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.ApplicationService
+	id, diags := convertStateIDToNummeric("Update OAuth2 Application", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := awxService.UpdateApplication(id, oauth2ApplicationData(d), map[string]string{}); err != nil {
+		return buildDiagUpdateFail("OAuth2 Application", id, err, d)
+	}
+	return resourceOAuth2ApplicationRead(ctx, d, m)
+}
+
+func resourceOAuth2ApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.ApplicationService
+	id, diags := convertStateIDToNummeric("Read OAuth2 Application", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, err := awxService.GetApplicationByID(id, map[string]string{})
+	if err != nil {
+		return buildDiagReadFail("OAuth2 Application", id, err, d)
+	}
+	setOAuth2ApplicationResourceData(d, res)
+	return nil
+}
+
+func resourceOAuth2ApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.ApplicationService
+	id, diags := convertStateIDToNummeric("Delete OAuth2 Application", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := awxService.DeleteApplication(id); err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("OAuth2 Application", fmt.Sprintf("ApplicationID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return nil
+}
+
+func setOAuth2ApplicationResourceData(d *schema.ResourceData, r *awx.Application) *schema.ResourceData {
+	d.Set("name", r.Name)
+	d.Set("description", r.Description)
+	d.Set("organization_id", r.OrganizationID)
+	d.Set("client_type", r.ClientType)
+	d.Set("authorization_grant_type", r.AuthorizationGrantType)
+	d.Set("redirect_uris", strings.Fields(r.RedirectURIs))
+	d.Set("skip_authorization", r.SkipAuthorization)
+	d.Set("client_id", r.ClientID)
+	return d
+}
@@ -58,9 +58,7 @@ func resourceOrganization() *schema.Resource {
 				Description: "The default execution environment for jobs run by this organization.",
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(organizationKey),
 		//
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
@@ -71,7 +69,6 @@ func resourceOrganization() *schema.Resource {
 }
 
 func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.OrganizationsService
 
@@ -89,12 +86,7 @@ func resourceOrganizationsCreate(ctx context.Context, d *schema.ResourceData, m
 	result, err := awxService.CreateOrganization(orgData, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Organization %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s, failed to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create Organizations", fmt.Sprintf("Organizations with name %s, failed to create", d.Get("name").(string)), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -114,7 +106,7 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	_, err := awxService.GetOrganizationsByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("Organizations", id, err)
+		return buildDiagFetchFail("Organizations", id, err)
 	}
 
     orgData := map[string]interface{}{
@@ -129,12 +121,7 @@ func resourceOrganizationsUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	_, err = awxService.UpdateOrganization(id, orgData, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Organizations",
-			Detail:   fmt.Sprintf("Organizations with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update Organizations", fmt.Sprintf("Organizations with name %s failed to update", d.Get("name").(string)), err, d)
 	}
 
 	return resourceOrganizationsRead(ctx, d, m)
@@ -151,7 +138,7 @@ func resourceOrganizationsRead(ctx context.Context, d *schema.ResourceData, m in
 
 	res, err := awxService.GetOrganizationsByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("Organization", id, err)
+		return buildDiagReadFail("Organization", id, err, d)
 
 	}
 	d = setOrganizationsResourceData(d, res)
@@ -168,7 +155,7 @@ func resourceOrganizationsDelete(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	if _, err := awxService.DeleteOrganization(id); err != nil {
+	if _, err := awxService.DeleteOrganization(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(digMessagePart, fmt.Sprintf("OrganizationID %v, got %s ", id, err.Error()))
 	}
 	d.SetId("")
@@ -42,6 +42,7 @@ func resourceOrganizationsGalaxyCredentials() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("organization_id", organizationKey, "credential_id", credentialKey),
 	}
 }
 
@@ -68,8 +69,7 @@ func resourceOrganizationsGalaxyCredentialsCreate(ctx context.Context, d *schema
 }
 
 func resourceOrganizationsGalaxyCredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	return readAssociation("Organization galaxy credential", "/api/v2/organizations/%d/galaxy_credentials/", "organization_id", "credential_id")(ctx, d, m)
 }
 
 func resourceOrganizationsGalaxyCredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -78,6 +78,10 @@ func resourceOrganizationsGalaxyCredentialsDelete(ctx context.Context, d *schema
 	awxService := client.OrganizationsService
 	OrganizationID := d.Get("organization_id").(int)
 	res, err := awxService.GetOrganizationsByID(OrganizationID, make(map[string]string))
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return buildDiagNotFoundFail("organization", OrganizationID, err)
 	}
@@ -85,7 +89,7 @@ func resourceOrganizationsGalaxyCredentialsDelete(ctx context.Context, d *schema
 	_, err = awxService.DisAssociateGalaxyCredentials(res.ID, map[string]interface{}{
 		"id": d.Get("credential_id").(int),
 	}, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail("Organization DisAssociateGalaxyCredentials", fmt.Sprintf("DisAssociateGalaxyCredentials %v, from OrganizationID %v got %s ", d.Get("credential_id").(int), d.Get("organization_id").(int), err.Error()))
 	}
 
@@ -49,12 +49,12 @@ func resourceOrganizationInstanceGroup() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("organization_id", organizationKey, "instance_group_id", instanceGroupKey),
 	}
 }
 
 func resourceOrganizationInstanceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+	return readAssociation("Organization instance group", "/api/v2/organizations/%d/instance_groups/", "organization_id", "instance_group_id")(ctx, d, m)
 }
 
 func resourceOrganizationInstanceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -85,6 +85,10 @@ func resourceOrganizationInstanceGroupDelete(ctx context.Context, d *schema.Reso
 	awxService := client.OrganizationsService
 	OrganizationID := d.Get("organization_id").(int)
 	res, err := awxService.GetOrganizationsByID(OrganizationID, make(map[string]string))
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return buildDiagNotFoundFail("organization", OrganizationID, err)
 	}
@@ -92,7 +96,7 @@ func resourceOrganizationInstanceGroupDelete(ctx context.Context, d *schema.Reso
 	_, err = awxService.DisAssociateInstanceGroups(res.ID, map[string]interface{}{
 		"id": d.Get("instance_group_id").(int),
 	}, map[string]string{})
-	if err != nil {
+	if err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail("Organization DisAssociateInstanceGroups", fmt.Sprintf("Fail to disassociate Instance Group %v from Organization %v, got error: %s ", d.Get("instance_group_id").(int), d.Get("organization_id").(int), err.Error()))
 	}
 
@@ -16,6 +16,14 @@
 	  scm_branch           = "feature/centos8-v2"
 	  scm_update_on_launch = true
 	  organization_id      = data.awx_organization.default.id
+	  wait_for_sync        = true
+	}
+
+	resource "awx_job_template" "baseconfig" {
+	  name       = "baseconfig"
+	  job_type   = "run"
+	  project_id = awx_project.base_service_config.id
+	  playbook   = contains(awx_project.base_service_config.playbooks, "site.yml") ? "site.yml" : "main.yml"
 	}
 
 ```
@@ -30,6 +38,7 @@ import (
 
 	awx "github.com/denouche/goawx/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -115,19 +124,132 @@ func resourceProject() *schema.Resource {
 				Default:     false,
 				Description: "Allow SCM branch override",
 			},
+			"wait_for_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the SCM update triggered by the creation of the project, or by a change of its SCM settings, to finish.",
+			},
+			"scm_revision": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Revision of the project checked out by the last SCM update.",
+			},
+			"last_update_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last SCM update of the project.",
+			},
+			"playbooks": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Playbooks discovered in the project by the last SCM update.",
+			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(projectKey),
 
 		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 	}
 }
 
+const (
+	projectPlaybooksAPIEndpoint = "/api/v2/projects/%d/playbooks/"
+	projectUpdateAPIEndpoint    = "/api/v2/project_updates/%d/"
+)
+
+// projectUpdateID returns the ID of the running, or else the latest, SCM update of a project.
+func projectUpdateID(p *awx.Project) int {
+	if p.SummaryFields == nil {
+		return 0
+	}
+	if p.SummaryFields.CurrentJob["id"] != nil {
+		return int(p.SummaryFields.CurrentJob["id"].(float64))
+	}
+	if p.SummaryFields.LastJob["id"] != nil {
+		return int(p.SummaryFields.LastJob["id"].(float64))
+	}
+	return 0
+}
+
+func statusProjectUpdateState(ctx context.Context, svc *awx.ProjectUpdatesService, id int) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := svc.ProjectUpdateGet(id)
+		if err != nil {
+			return nil, "", err
+		}
+		return output, output.Status, nil
+	}
+}
+
+// projectSCMArguments are the arguments changing what the project checks out.
+var projectSCMArguments = []string{"scm_type", "scm_url", "scm_branch", "scm_credential_id"}
+
+// projectSyncWait waits for the SCM update started by the creation or the
+// update of a project to finish. previousUpdateID is the latest SCM update
+// before the change: when AWX did not start a new one, the project is updated
+// so that an older update is never waited on.
+func projectSyncWait(ctx context.Context, d *schema.ResourceData, m interface{}, previousUpdateID int, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*awx.AWX)
+	id, diags := convertStateIDToNummeric("Wait Project Sync", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	project, err := client.ProjectService.GetProjectByID(id, make(map[string]string))
+	if err != nil {
+		return buildDiagFetchFail("project", id, err)
+	}
+	if project.ScmType == "" {
+		return diags
+	}
+	updateID := projectUpdateID(project)
+	if updateID == previousUpdateID {
+		res := new(projectUpdateLaunch)
+		if err := apiClientFor(m).postJSON(fmt.Sprintf(projectUpdateLaunchAPIEndpoint, id), map[string]interface{}{}, res); err != nil {
+			return buildDiagnosticsMessage(
+				"Project sync failure",
+				"Project with ID %d, unable to start an SCM update %s", id, err.Error(),
+			)
+		}
+		updateID = res.ProjectUpdate
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    jobPendingStatuses,
+		Target:     jobFinalStatuses,
+		Refresh:    statusProjectUpdateState(ctx, client.ProjectUpdatesService, updateID),
+		Timeout:    timeout,
+		Delay:      2 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return buildDiagnosticsMessage(
+			"Project sync failure",
+			"Project with ID %d, SCM update %d failed to complete %s", id, updateID, err.Error(),
+		)
+	}
+
+	if update := result.(*awx.Job); update.Status != awx.JobStatusSuccessful {
+		api := apiClientFor(m)
+		return buildDiagJobFailed(
+			"ProjectUpdate",
+			updateID,
+			update.Status,
+			api.uiURL("/jobs/project/%d/output", updateID),
+			update.JobExplanation,
+			jobStdoutTail(api, fmt.Sprintf(projectUpdateAPIEndpoint, updateID)),
+		)
+	}
+	return diags
+}
+
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*awx.AWX)
 	awxService := client.ProjectService
@@ -166,10 +288,15 @@ func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interf
 		"allow_override":           d.Get("allow_override").(bool),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Create: Project not created", "Project with name %s  in the Organization ID %v not created, %s", projectName, orgID, err.Error())
+		return buildDiagAPIFail("Create: Project not created", fmt.Sprintf("Project with name %s  in the Organization ID %v not created", projectName, orgID), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
+	if d.Get("wait_for_sync").(bool) {
+		if diags := projectSyncWait(ctx, d, m, 0, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+			return diags
+		}
+	}
 	return resourceProjectRead(ctx, d, m)
 }
 
@@ -206,9 +333,24 @@ func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		data["local_path"] = d.Get("local_path").(string)
 	}
 
+	waitForSync := d.Get("wait_for_sync").(bool) && d.HasChanges(projectSCMArguments...)
+	previousUpdateID := 0
+	if waitForSync {
+		project, err := awxService.GetProjectByID(id, make(map[string]string))
+		if err != nil {
+			return buildDiagFetchFail("project", id, err)
+		}
+		previousUpdateID = projectUpdateID(project)
+	}
+
 	_, err := awxService.UpdateProject(id, data, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Update: Fail To Update Project", "Fail to get Project with ID %v, got %s", id, err.Error())
+		return buildDiagAPIFail("Update: Fail To Update Project", fmt.Sprintf("Fail to get Project with ID %v", id), err, d)
+	}
+	if waitForSync {
+		if diags := projectSyncWait(ctx, d, m, previousUpdateID, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
 	}
 	return resourceProjectRead(ctx, d, m)
 }
@@ -225,9 +367,17 @@ func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	res, err := awxService.GetProjectByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("project", id, err)
+		return buildDiagReadFail("project", id, err, d)
 	}
 	d = setProjectResourceData(d, res)
+	d.Set("scm_revision", res.ScmRevision)
+	d.Set("last_update_status", res.Status)
+
+	playbooks := []string{}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf(projectPlaybooksAPIEndpoint, id), &playbooks, map[string]string{}); err != nil {
+		return buildDiagReadFail("project", id, err, d)
+	}
+	d.Set("playbooks", playbooks)
 	return diags
 }
 
@@ -244,16 +394,16 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	res, err := awxService.GetProjectByID(id, make(map[string]string))
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		d.SetId("")
 		return buildDiagNotFoundFail("project", id, err)
 	}
 
-	if res.SummaryFields.CurrentJob["id"] != nil {
-		jobID = int(res.SummaryFields.CurrentJob["id"].(float64))
-	} else if res.SummaryFields.LastJob["id"] != nil {
-		jobID = int(res.SummaryFields.LastJob["id"].(float64))
-	}
+	jobID = projectUpdateID(res)
 	if jobID != 0 {
 		_, err = client.ProjectUpdatesService.ProjectUpdateCancel(jobID)
 		if err != nil {
@@ -271,7 +421,7 @@ func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interf
 		time.Sleep(1 * time.Second)
 	}
 
-	if _, err = awxService.DeleteProject(id); err != nil {
+	if _, err = awxService.DeleteProject(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(digMessagePart, fmt.Sprintf("ProjectID %v, got %s ", id, err.Error()))
 	}
 	d.SetId("")
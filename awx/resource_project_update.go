@@ -0,0 +1,195 @@
+/*
+Trigger an SCM update of a project, for example after pushing a new branch, without recreating the project.
+The update is launched again whenever one of the triggers changes.
+
+Example Usage
+
+```hcl
+resource "awx_project_update" "refresh" {
+  project_id          = awx_project.base_service_config.id
+  wait_for_completion = true
+
+  triggers = {
+    commit = var.playbooks_commit
+  }
+}
+```
+
+*/
+
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	awx "github.com/denouche/goawx/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceProjectUpdateLaunch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectUpdateLaunchCreate,
+		ReadContext:   resourceProjectUpdateLaunchRead,
+		UpdateContext: resourceProjectUpdateLaunchUpdate,
+		DeleteContext: resourceProjectUpdateLaunchDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Project ID",
+				ForceNew:    true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, will update the project again.",
+				ForceNew:    true,
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Resource creation will wait for the project update completion.",
+				ForceNew:    true,
+			},
+			"cancel_on_interrupt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Cancel the project update when Terraform is interrupted or the create timeout expires while waiting for its completion.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the project update.",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the project update failed.",
+			},
+			"scm_revision": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Revision of the project checked out by the update.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+	}
+}
+
+const projectUpdateLaunchAPIEndpoint = "/api/v2/projects/%d/update/"
+
+// projectUpdateLaunch is the response of the project update endpoint.
+type projectUpdateLaunch struct {
+	ID            int `json:"id"`
+	ProjectUpdate int `json:"project_update"`
+}
+
+// projectUpdateOutcome holds the fields of a project update exposed by awx_project_update.
+type projectUpdateOutcome struct {
+	jobOutcome
+	ScmRevision string `json:"scm_revision"`
+}
+
+func resourceProjectUpdateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := m.(*awx.AWX)
+	api := apiClientFor(m)
+
+	projectID := d.Get("project_id").(int)
+	if _, err := client.ProjectService.GetProjectByID(projectID, make(map[string]string)); err != nil {
+		return buildDiagNotFoundFail("project", projectID, err)
+	}
+
+	res := new(projectUpdateLaunch)
+	if err := api.postJSON(fmt.Sprintf(projectUpdateLaunchAPIEndpoint, projectID), map[string]interface{}{}, res); err != nil {
+		log.Printf("Failed to update Project %v", err)
+		return buildDiagnosticsMessage(
+			"Unable to update Project",
+			"ProjectUpdate with project ID %d, failed to create %s", projectID, err.Error(),
+		)
+	}
+	updateID := res.ProjectUpdate
+	if updateID == 0 {
+		updateID = res.ID
+	}
+	d.SetId(strconv.Itoa(updateID))
+
+	if d.Get("wait_for_completion").(bool) {
+		stateConf := &retry.StateChangeConf{
+			Pending:    jobPendingStatuses,
+			Target:     jobFinalStatuses,
+			Refresh:    statusProjectUpdateState(ctx, client.ProjectUpdatesService, updateID),
+			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Delay:      2 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+		result, err := stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			if isWaitInterrupted(ctx, err) && d.Get("cancel_on_interrupt").(bool) {
+				if err := api.postJSON(fmt.Sprintf(projectUpdateAPIEndpoint, updateID)+"cancel/", map[string]interface{}{}, nil); err != nil {
+					log.Printf("Failed to cancel ProjectUpdate %d %v", updateID, err)
+				}
+			}
+			diags = append(diags, buildDiagnosticsMessage(
+				"ProjectUpdate execution failure",
+				"ProjectUpdate with ID %d and project ID %d, failed to complete %s", updateID, projectID, err.Error(),
+			)...)
+		} else if update := result.(*awx.Job); update.Status != awx.JobStatusSuccessful {
+			diags = append(diags, buildDiagJobFailed(
+				"ProjectUpdate",
+				updateID,
+				update.Status,
+				api.uiURL("/jobs/project/%d/output", updateID),
+				update.JobExplanation,
+				jobStdoutTail(api, fmt.Sprintf(projectUpdateAPIEndpoint, updateID)),
+			)...)
+		}
+	}
+	return append(diags, resourceProjectUpdateLaunchRead(ctx, d, m)...)
+}
+
+func resourceProjectUpdateLaunchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	id, diags := convertStateIDToNummeric("Read ProjectUpdate", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	update := new(projectUpdateOutcome)
+	if err := apiClientFor(m).getJSON(fmt.Sprintf(projectUpdateAPIEndpoint, id), update, map[string]string{}); err != nil {
+		if isNotFound(err) {
+			// AWX purges old updates, the update itself still happened so keep the last known state.
+			log.Printf("Project update %d not found, keeping its last known outcome", id)
+			return diags
+		}
+		return buildDiagNotFoundFail("project update", id, err)
+	}
+
+	d.Set("status", update.Status)
+	d.Set("failed", update.Failed)
+	d.Set("scm_revision", update.ScmRevision)
+	return diags
+}
+
+// resourceProjectUpdateLaunchUpdate only stores the new cancel_on_interrupt, every other argument forces a new update.
+func resourceProjectUpdateLaunchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceProjectUpdateLaunchRead(ctx, d, m)
+}
+
+func resourceProjectUpdateLaunchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
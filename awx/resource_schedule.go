@@ -67,14 +67,11 @@ func resourceSchedule() *schema.Resource {
 				Description: "Extra data to be pass for the schedule (YAML format)",
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer: importStateNaturalKey(scheduleKey),
 	}
 }
 
 func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.ScheduleService
 
@@ -93,12 +90,7 @@ func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, m inter
 	result, err := awxService.Create(scheduleData, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Schedule %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Schedule",
-			Detail:   fmt.Sprintf("Schedule failed to create %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create Schedule", "Schedule failed to create", err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -117,7 +109,7 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	params := make(map[string]string)
 	_, err := awxService.GetByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("schedule", id, err)
+		return buildDiagFetchFail("schedule", id, err)
 	}
 
 	scheduleData := map[string]interface{}{
@@ -134,12 +126,7 @@ func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	_, err = awxService.Update(id, scheduleData, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update Schedule",
-			Detail:   fmt.Sprintf("Schedule with name %s failed to update %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update Schedule", fmt.Sprintf("Schedule with name %s failed to update", d.Get("name").(string)), err, d)
 	}
 
 	return resourceScheduleRead(ctx, d, m)
@@ -156,7 +143,7 @@ func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 	res, err := awxService.GetByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("schedule", id, err)
+		return buildDiagReadFail("schedule", id, err, d)
 
 	}
 	d = setScheduleResourceData(d, res)
@@ -171,7 +158,7 @@ func resourceScheduleDelete(ctx context.Context, d *schema.ResourceData, m inter
 		return diags
 	}
 
-	if _, err := awxService.Delete(id); err != nil {
+	if _, err := awxService.Delete(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementHostTitle,
 			fmt.Sprintf("id %v, got %s ",
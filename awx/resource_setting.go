@@ -1,6 +1,8 @@
 /*
 This resource configure generic AWX settings.
-Please note that resource deletion only delete object from terraform state and do not reset setting to his initial value.
+By default resource deletion only delete object from terraform state and do not reset setting to his initial value.
+Set reset_on_destroy to restore the default value of the setting on deletion, or restore_previous to restore the value
+it had before terraform managed it.
 
 See available settings list here: https://docs.ansible.com/ansible-tower/latest/html/towerapi/api_ref.html#/Settings/Settings_settings_update
 
@@ -23,8 +25,9 @@ resource "awx_setting" "social_auth_saml_sp_entity_id" {
 }
 
 resource "awx_setting" "schedule_max_jobs" {
-  name  = "SCHEDULE_MAX_JOBS"
-  value = 15
+  name             = "SCHEDULE_MAX_JOBS"
+  value            = 15
+  reset_on_destroy = true
 }
 
 resource "awx_setting" "remote_host_headers" {
@@ -45,6 +48,8 @@ package awx
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 
 	awx "github.com/denouche/goawx/client"
@@ -52,9 +57,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// encryptedValue is what AWX returns in place of the value of secret settings.
+const encryptedValue = "$encrypted$"
+
 func resourceSetting() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceSettingUpdate,
+		CreateContext: resourceSettingCreate,
 		ReadContext:   resourceSettingRead,
 		DeleteContext: resourceSettingDelete,
 		UpdateContext: resourceSettingUpdate,
@@ -63,12 +71,34 @@ func resourceSetting() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 				Description: "Name of setting to modify",
 			},
 			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Value to be modified for given setting.",
+				DiffSuppressFunc: suppressEquivalentSettingValue,
+			},
+			"reset_on_destroy": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"restore_previous"},
+				Description:   "Reset the setting to its default value when the resource is destroyed",
+			},
+			"restore_previous": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"reset_on_destroy"},
+				Description:   "Restore the value the setting had before the resource was created when it is destroyed",
+			},
+			"previous_value": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Value to be modified for given setting.",
+				Computed:    true,
+				Sensitive:   true,
+				Description: "JSON value of the setting before the resource was created, restored on destroy with restore_previous",
 			},
 		},
 		Importer: &schema.ResourceImporter{
@@ -85,43 +115,61 @@ func resourceSetting() *schema.Resource {
 
 type setting map[string]string
 
-func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// settingsMetadata is the part of the OPTIONS of the settings holding their defaults.
+type settingsMetadata struct {
+	Actions struct {
+		PUT map[string]map[string]json.RawMessage `json:"PUT"`
+	} `json:"actions"`
+}
+
+func resourceSettingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*awx.AWX)
 	awxService := client.SettingService
 
-	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
+	res, err := awxService.GetSettingsBySlug("all", make(map[string]string))
 	if err != nil {
 		return buildDiagnosticsMessage(
 			"Create: failed to fetch settings",
 			"Failed to fetch setting, got: %s", err.Error(),
 		)
 	}
-
-	var map_decoded map[string]interface{}
-	var array_decoded []interface{}
-	var formatted_value interface{}
-
+	var diags diag.Diagnostics
 	name := d.Get("name").(string)
-	value := d.Get("value").(string)
+	if previous, ok := (*res)[name]; ok {
+		var previousValue interface{}
+		if err := json.Unmarshal(previous, &previousValue); err == nil && containsEncryptedValue(previousValue) {
+			// Restoring it would write the placeholder in place of the secret.
+			if d.Get("restore_previous").(bool) {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Setting cannot be restored",
+					Detail:   fmt.Sprintf("AWX hides the value of the secret setting %s, restore_previous will leave it as is on destroy.", name),
+				})
+			}
+		} else {
+			d.Set("previous_value", string(previous))
+		}
+	}
+	return append(diags, resourceSettingUpdate(ctx, d, m)...)
+}
 
-	// Attempt to unmarshall string into a map
-	err = json.Unmarshal([]byte(value), &map_decoded)
+func resourceSettingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.SettingService
 
+	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
 	if err != nil {
-		// Attempt to unmarshall string into an array
-		err = json.Unmarshal([]byte(value), &array_decoded)
-
-		if err != nil {
-			formatted_value = value
-		} else {
-			formatted_value = array_decoded
-		}
-	} else {
-		formatted_value = map_decoded
+		return buildDiagnosticsMessage(
+			"Create: failed to fetch settings",
+			"Failed to fetch setting, got: %s", err.Error(),
+		)
 	}
 
+	name := d.Get("name").(string)
+	value := d.Get("value").(string)
+
 	payload := map[string]interface{}{
-		name: formatted_value,
+		name: encodeSettingValue(value),
 	}
 
 	_, err = awxService.UpdateSettings("all", payload, make(map[string]string))
@@ -141,7 +189,7 @@ func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interfac
 	client := m.(*awx.AWX)
 	awxService := client.SettingService
 
-	_, err := awxService.GetSettingsBySlug("all", make(map[string]string))
+	res, err := awxService.GetSettingsBySlug("all", make(map[string]string))
 	if err != nil {
 		return buildDiagnosticsMessage(
 			"Unable to fetch settings",
@@ -149,14 +197,162 @@ func resourceSettingRead(ctx context.Context, d *schema.ResourceData, m interfac
 		)
 	}
 
+	raw, ok := (*res)[d.Id()]
+	if !ok {
+		return removeFromState(d, "setting "+d.Id())
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return buildDiagnosticsMessage(
+			"Unable to parse setting",
+			"Unable to parse setting %s, got: %s", d.Id(), err.Error(),
+		)
+	}
+
 	d.Set("name", d.Id())
-	d.Set("value", d.Get("value").(string))
+	// AWX hides the value of secret settings, and of the secret members of
+	// objects such as the SAML and LDAP ones, keep the ones Terraform set.
+	value = restoreEncryptedValues(value, encodeSettingValue(d.Get("value").(string)))
+	if value != encryptedValue {
+		d.Set("value", settingValueString(value))
+	}
 	return diags
 }
 
+// restoreEncryptedValues replaces the encryptedValue placeholders found in
+// value by the matching part of configured.
+func restoreEncryptedValues(value, configured interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == encryptedValue && configured != nil && configured != "" {
+			return configured
+		}
+	case map[string]interface{}:
+		configuredMap, _ := configured.(map[string]interface{})
+		for key, member := range v {
+			v[key] = restoreEncryptedValues(member, configuredMap[key])
+		}
+	case []interface{}:
+		configuredList, _ := configured.([]interface{})
+		for i, item := range v {
+			var configuredItem interface{}
+			if i < len(configuredList) {
+				configuredItem = configuredList[i]
+			}
+			v[i] = restoreEncryptedValues(item, configuredItem)
+		}
+	}
+	return value
+}
+
+// containsEncryptedValue reports whether AWX hid value, or one of its members.
+func containsEncryptedValue(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return v == encryptedValue
+	case map[string]interface{}:
+		for _, member := range v {
+			if containsEncryptedValue(member) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if containsEncryptedValue(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// encodeSettingValue returns the value sent to AWX for the value of a
+// setting: JSON objects and arrays are decoded, anything else is sent as a
+// string that AWX converts to the type of the setting.
+func encodeSettingValue(value string) interface{} {
+	var mapDecoded map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &mapDecoded); err == nil {
+		return mapDecoded
+	}
+	var arrayDecoded []interface{}
+	if err := json.Unmarshal([]byte(value), &arrayDecoded); err == nil {
+		return arrayDecoded
+	}
+	return value
+}
+
+// settingValueString returns the canonical form of a setting value kept in
+// the state: compact JSON for objects and arrays, plain text for strings,
+// booleans and numbers, and an empty string for null.
+func settingValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// suppressEquivalentSettingValue ignores the differences of formatting between
+// the configured value and the canonical form read from AWX, such as 1.0 for 1.
+func suppressEquivalentSettingValue(k, old, new string, d *schema.ResourceData) bool {
+	oldValue := settingValueString(encodeSettingValue(old))
+	newValue := settingValueString(encodeSettingValue(new))
+	if oldValue == newValue {
+		return true
+	}
+	oldNumber, oldErr := strconv.ParseFloat(oldValue, 64)
+	newNumber, newErr := strconv.ParseFloat(newValue, 64)
+	return oldErr == nil && newErr == nil && oldNumber == newNumber
+}
+
 func resourceSettingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	client := m.(*awx.AWX)
+	awxService := client.SettingService
+	name := d.Id()
+
+	var value json.RawMessage
+	switch {
+	case d.Get("restore_previous").(bool):
+		previous := d.Get("previous_value").(string)
+		if previous == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Setting not restored",
+				Detail:   fmt.Sprintf("The value of %s before its creation is unknown, such as after an import or for a secret setting, it was left as is.", name),
+			})
+			d.SetId("")
+			return diags
+		}
+		value = json.RawMessage(previous)
+	case d.Get("reset_on_destroy").(bool):
+		api := apiClientFor(m)
+		if api == nil {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("unable to fetch the default of %s, the provider is not configured", name))
+		}
+		metadata := settingsMetadata{}
+		if err := api.optionsJSON("/api/v2/settings/all/", &metadata); err != nil {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("unable to fetch the default of %s, got %s", name, err.Error()))
+		}
+		defaultValue, ok := metadata.Actions.PUT[name]["default"]
+		if !ok {
+			return buildDiagDeleteFail("setting", fmt.Sprintf("AWX describes no default for %s, unset reset_on_destroy to leave it as is", name))
+		}
+		value = defaultValue
+	default:
+		d.SetId("")
+		return diags
+	}
 
+	if _, err := awxService.UpdateSettings("all", map[string]interface{}{name: value}, make(map[string]string)); err != nil {
+		return buildDiagDeleteFail("setting", fmt.Sprintf("unable to reset %s, got %s", name, err.Error()))
+	}
 	d.SetId("")
 	return diags
 }
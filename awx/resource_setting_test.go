@@ -0,0 +1,166 @@
+package awx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeSettingValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected interface{}
+	}{
+		{name: "empty", value: "", expected: ""},
+		{name: "string", value: "test", expected: "test"},
+		{name: "number", value: "15", expected: "15"},
+		{name: "boolean", value: "true", expected: "true"},
+		{name: "object", value: `{"givenName": "Myorg", "emailAddress": "test@foo.com"}`, expected: map[string]interface{}{"givenName": "Myorg", "emailAddress": "test@foo.com"}},
+		{name: "array", value: "[\n  \"HTTP_X_FORWARDED_FOR\",\n  \"REMOTE_ADDR\"\n]", expected: []interface{}{"HTTP_X_FORWARDED_FOR", "REMOTE_ADDR"}},
+		{name: "invalid json", value: `{"givenName":`, expected: `{"givenName":`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := encodeSettingValue(c.value); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("encodeSettingValue(%q) = %#v, expected %#v", c.value, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestSettingValueString(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "null", value: `null`, expected: ""},
+		{name: "string", value: `"test"`, expected: "test"},
+		{name: "integer", value: `15`, expected: "15"},
+		{name: "float", value: `0.5`, expected: "0.5"},
+		{name: "boolean", value: `false`, expected: "false"},
+		{name: "object", value: `{ "b": 1, "a": [true, null] }`, expected: `{"a":[true,null],"b":1}`},
+		{name: "array", value: `[ "REMOTE_ADDR" ]`, expected: `["REMOTE_ADDR"]`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(c.value), &value); err != nil {
+				t.Fatal(err)
+			}
+			if actual := settingValueString(value); actual != c.expected {
+				t.Errorf("settingValueString(%s) = %q, expected %q", c.value, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentSettingValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{name: "same string", old: "test", new: "test", expected: true},
+		{name: "different string", old: "test", new: "other", expected: false},
+		{name: "same number", old: "15", new: "15", expected: true},
+		{name: "float and integer", old: "1", new: "1.0", expected: true},
+		{name: "exponent", old: "1000", new: "1e3", expected: true},
+		{name: "different number", old: "1", new: "1.5", expected: false},
+		{name: "number and string", old: "1", new: "one", expected: false},
+		{name: "object formatting", old: `{"a":1,"b":"x"}`, new: "{\n  \"b\": \"x\",\n  \"a\": 1.0\n}", expected: true},
+		{name: "different object", old: `{"a":1}`, new: `{"a":2}`, expected: false},
+		{name: "array formatting", old: `["a","b"]`, new: "[\n  \"a\",\n  \"b\"\n]", expected: true},
+		{name: "array order", old: `["a","b"]`, new: `["b","a"]`, expected: false},
+		{name: "empty", old: "", new: "", expected: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if actual := suppressEquivalentSettingValue("value", c.old, c.new, nil); actual != c.expected {
+				t.Errorf("suppressEquivalentSettingValue(%q, %q) = %t, expected %t", c.old, c.new, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestRestoreEncryptedValues(t *testing.T) {
+	cases := []struct {
+		name       string
+		value      string
+		configured string
+		expected   string
+	}{
+		{name: "top level", value: `"$encrypted$"`, configured: "s3cr3t", expected: "s3cr3t"},
+		{name: "top level unknown", value: `"$encrypted$"`, configured: "", expected: "$encrypted$"},
+		{name: "plain", value: `"test"`, configured: "other", expected: "test"},
+		{
+			name:       "object member",
+			value:      `{"BIND_DN":"cn=admin","BIND_PASSWORD":"$encrypted$"}`,
+			configured: `{"BIND_DN":"cn=other","BIND_PASSWORD":"s3cr3t"}`,
+			expected:   `{"BIND_DN":"cn=admin","BIND_PASSWORD":"s3cr3t"}`,
+		},
+		{
+			name:       "nested member",
+			value:      `{"idp":{"x509cert":"$encrypted$","url":"https://idp"}}`,
+			configured: `{"idp":{"x509cert":"MIIC","url":"https://idp"}}`,
+			expected:   `{"idp":{"url":"https://idp","x509cert":"MIIC"}}`,
+		},
+		{
+			name:       "array item",
+			value:      `[{"key":"$encrypted$"},{"key":"$encrypted$"}]`,
+			configured: `[{"key":"a"}]`,
+			expected:   `[{"key":"a"},{"key":"$encrypted$"}]`,
+		},
+		{
+			name:       "member not configured",
+			value:      `{"BIND_PASSWORD":"$encrypted$"}`,
+			configured: `{}`,
+			expected:   `{"BIND_PASSWORD":"$encrypted$"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(c.value), &value); err != nil {
+				t.Fatal(err)
+			}
+			actual := settingValueString(restoreEncryptedValues(value, encodeSettingValue(c.configured)))
+			if actual != c.expected {
+				t.Errorf("restoreEncryptedValues(%s, %q) = %q, expected %q", c.value, c.configured, actual, c.expected)
+			}
+		})
+	}
+}
+
+func TestContainsEncryptedValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "top level", value: `"$encrypted$"`, expected: true},
+		{name: "plain", value: `"test"`, expected: false},
+		{name: "number", value: `15`, expected: false},
+		{name: "object member", value: `{"BIND_DN":"cn=admin","BIND_PASSWORD":"$encrypted$"}`, expected: true},
+		{name: "array item", value: `[{"key":"a"},{"key":"$encrypted$"}]`, expected: true},
+		{name: "object", value: `{"givenName":"Myorg","emailAddress":"test@foo.com"}`, expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(c.value), &value); err != nil {
+				t.Fatal(err)
+			}
+			if actual := containsEncryptedValue(value); actual != c.expected {
+				t.Errorf("containsEncryptedValue(%s) = %t, expected %t", c.value, actual, c.expected)
+			}
+		})
+	}
+}
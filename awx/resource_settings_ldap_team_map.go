@@ -234,10 +234,7 @@ func resourceSettingsLDAPTeamMapRead(ctx context.Context, d *schema.ResourceData
 	}
 	mapdef, ok := tmaps[d.Id()]
 	if !ok {
-		return buildDiagnosticsMessage(
-			"Unable to fetch ldap team map",
-			"Unable to load ldap team map %v: not found", d.Id(),
-		)
+		return removeFromState(d, "ldap team map "+d.Id())
 	}
 
 	/*return buildDiagnosticsMessage(
@@ -91,9 +91,7 @@ func resourceTeam() *schema.Resource {
 				},
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(teamKey),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(1 * time.Minute),
@@ -127,7 +125,7 @@ func resourceTeamCreate(ctx context.Context, d *schema.ResourceData, m interface
 		"organization": d.Get("organization_id").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Create: Team not created", "Team with name %s  in the Organization ID %v not created, %s", teamName, orgID, err.Error())
+		return buildDiagAPIFail("Create: Team not created", fmt.Sprintf("Team with name %s  in the Organization ID %v not created", teamName, orgID), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -211,7 +209,7 @@ func resourceTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"organization": d.Get("organization_id").(int),
 	}, map[string]string{})
 	if err != nil {
-		return buildDiagnosticsMessage("Update: Failed To Update Team", "Fail to get Team with ID %v, got %s", id, err.Error())
+		return buildDiagAPIFail("Update: Failed To Update Team", fmt.Sprintf("Fail to get Team with ID %v", id), err, d)
 	}
 	d.Partial(false)
 	return resourceTeamRead(ctx, d, m)
@@ -229,11 +227,11 @@ func resourceTeamRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	team, err := awxService.GetTeamByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("team", id, err)
+		return buildDiagReadFail("team", id, err, d)
 	}
 	entitlements, _, err := awxService.ListTeamRoleEntitlements(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("team roles", id, err)
+		return buildDiagReadFail("team", id, err, d)
 	}
 
 	d = setTeamResourceData(d, team, entitlements)
@@ -251,7 +249,7 @@ func resourceTeamDelete(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	if _, err := awxService.DeleteTeam(id); err != nil {
+	if _, err := awxService.DeleteTeam(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(digMessagePart, fmt.Sprintf("TeamID %v, got %s ", id, err.Error()))
 	}
 	d.SetId("")
@@ -0,0 +1,187 @@
+/*
+Create a personal access token of the user the provider authenticates as, or
+a token of an OAuth2 application when application_id is set.
+
+Example Usage
+
+```hcl
+resource "awx_token" "ci" {
+  description    = "CI pipeline"
+  application_id = awx_oauth2_application.ci.id
+  scope          = "write"
+
+  rotate_when = {
+    quarter = "2024-Q3"
+  }
+}
+```
+
+*/
+package awx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	tokensAPIEndpoint = "/api/v2/tokens/"
+	tokenAPIEndpoint  = "/api/v2/tokens/%d/"
+)
+
+func resourceToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTokenCreate,
+		ReadContext:   resourceTokenRead,
+		UpdateContext: resourceTokenUpdate,
+		DeleteContext: resourceTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"application_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "OAuth2 application the token belongs to, a personal access token is created when unset",
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "write",
+				Description: "Allowed scope of the token: read or write",
+			},
+			"rotate_when": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will replace the token with a new one.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret value of the token, only returned by AWX when the token is created",
+			},
+			"refresh_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Refresh token of an application token",
+			},
+			"expires": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry date of the token, a new token is created once it has expired",
+			},
+			"user_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// oauth2Token represents the awx api token.
+type oauth2Token struct {
+	ID           int    `json:"id,omitempty"`
+	Description  string `json:"description"`
+	Application  *int   `json:"application,omitempty"`
+	Scope        string `json:"scope"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Expires      string `json:"expires,omitempty"`
+	User         int    `json:"user,omitempty"`
+}
+
+func resourceTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	data := oauth2Token{
+		Description: d.Get("description").(string),
+		Scope:       d.Get("scope").(string),
+	}
+	if applicationID, ok := d.GetOk("application_id"); ok {
+		id := applicationID.(int)
+		data.Application = &id
+	}
+
+	result := oauth2Token{}
+	if err := apiClientFor(m).postJSON(tokensAPIEndpoint, data, &result); err != nil {
+		log.Printf("Fail to Create Token %v", err)
+		return buildDiagCreateFail("Token", err, d)
+	}
+
+	d.SetId(strconv.Itoa(result.ID))
+	d.Set("token", result.Token)
+	d.Set("refresh_token", result.RefreshToken)
+	return resourceTokenRead(ctx, d, m)
+}
+
+func resourceTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Update Token", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	data := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"scope":       d.Get("scope").(string),
+	}
+	if err := apiClientFor(m).patchJSON(fmt.Sprintf(tokenAPIEndpoint, id), data, nil); err != nil {
+		return buildDiagUpdateFail("Token", id, err, d)
+	}
+	return resourceTokenRead(ctx, d, m)
+}
+
+func resourceTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Read Token", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	result := oauth2Token{}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf(tokenAPIEndpoint, id), &result, map[string]string{}); err != nil {
+		return buildDiagReadFail("Token", id, err, d)
+	}
+
+	if expires, err := time.Parse(time.RFC3339, result.Expires); err == nil && expires.Before(time.Now()) {
+		log.Printf("Token %d expired on %s, it will be created again", id, result.Expires)
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Token %d expired", id),
+			Detail:   fmt.Sprintf("Token %d expired on %s, it was removed from the state and will be created again.", id, result.Expires),
+		}}
+	}
+
+	d.Set("description", result.Description)
+	if result.Application != nil {
+		d.Set("application_id", *result.Application)
+	}
+	d.Set("scope", result.Scope)
+	d.Set("expires", result.Expires)
+	d.Set("user_id", result.User)
+	return nil
+}
+
+func resourceTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, diags := convertStateIDToNummeric("Delete Token", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := apiClientFor(m).delete(fmt.Sprintf(tokenAPIEndpoint, id)); err != nil && !isNotFound(err) {
+		return buildDiagDeleteFail("Token", fmt.Sprintf("TokenID %v, got %s ", id, err.Error()))
+	}
+	d.SetId("")
+	return nil
+}
@@ -86,15 +86,11 @@ func resourceUser() *schema.Resource {
 				},
 			},
 		},
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+		Importer: importStateNaturalKey(userKey),
 	}
 }
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-
 	client := m.(*awx.AWX)
 	awxService := client.UserService
 	userName := d.Get("username").(string)
@@ -109,12 +105,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 		"is_system_auditor": d.Get("is_system_auditor").(bool),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create new user",
-			Detail:   fmt.Sprintf("Unable to create new user: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create new user", "Unable to create new user", err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -201,12 +192,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"is_system_auditor": d.Get("is_system_auditor").(bool),
 	}, nil)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update user",
-			Detail:   fmt.Sprintf("Unable to update new user: %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update user", "Unable to update new user", err, d)
 	}
 
 	return resourceUserRead(ctx, d, m)
@@ -220,16 +206,11 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	id, _ := strconv.Atoi(d.Id())
 	res, err := awxService.GetUserByID(id, make(map[string]string))
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to fetch user",
-			Detail:   fmt.Sprintf("Unable to fetch user: %s", err.Error()),
-		})
-		return diags
+		return buildDiagReadFail("user", id, err, d)
 	}
 	entitlements, _, err := awxService.ListUserRoleEntitlements(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("user roles", id, err)
+		return buildDiagReadFail("user", id, err, d)
 	}
 
 	d.Set("username", res.Username)
@@ -267,7 +248,7 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 		return diags
 	}
 
-	if _, err := awxService.DeleteUser(id); err != nil {
+	if _, err := awxService.DeleteUser(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			"User",
 			fmt.Sprintf("id %v, got %s ",
@@ -114,11 +114,11 @@ func resourceWorkflowJobTemplate() *schema.Resource {
 				Default:  "",
 			},
 		},
+		Importer: importStateNaturalKey(workflowJobTemplateKey),
 	}
 }
 
 func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateService
 
@@ -141,12 +141,7 @@ func resourceWorkflowJobTemplateCreate(ctx context.Context, d *schema.ResourceDa
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s failed to create %s", d.Get("name").(string), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create WorkflowJobTemplate", fmt.Sprintf("WorkflowJobTemplate with name %s failed to create", d.Get("name").(string)), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -165,7 +160,7 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 	params := make(map[string]string)
 	_, err := awxService.GetWorkflowJobTemplateByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("job Workflow template", id, err)
+		return buildDiagFetchFail("job Workflow template", id, err)
 	}
 
 	_, err = awxService.UpdateWorkflowJobTemplate(id, map[string]interface{}{
@@ -186,12 +181,7 @@ func resourceWorkflowJobTemplateUpdate(ctx context.Context, d *schema.ResourceDa
 		"webhook_credential":       d.Get("webhook_credential").(string),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update WorkflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplate with name %s in the project id %d failed to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update WorkflowJobTemplate", fmt.Sprintf("WorkflowJobTemplate with name %s in the project id %d failed to update", d.Get("name").(string), d.Get("project_id").(int)), err, d)
 	}
 
 	return resourceWorkflowJobTemplateRead(ctx, d, m)
@@ -208,7 +198,7 @@ func resourceWorkflowJobTemplateRead(ctx context.Context, d *schema.ResourceData
 
 	res, err := awxService.GetWorkflowJobTemplateByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("workflow job template", id, err)
+		return buildDiagReadFail("workflow job template", id, err, d)
 
 	}
 	d = setWorkflowJobTemplateResourceData(d, res)
@@ -223,7 +213,7 @@ func resourceWorkflowJobTemplateDelete(ctx context.Context, d *schema.ResourceDa
 		return diags
 	}
 
-	if _, err := awxService.DeleteWorkflowJobTemplate(id); err != nil {
+	if _, err := awxService.DeleteWorkflowJobTemplate(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementHostTitle,
 			fmt.Sprintf("id %v, got %s ",
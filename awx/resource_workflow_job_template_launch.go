@@ -8,6 +8,18 @@ resource "awx_workflow_job_template_launch" "now" {
   workflow_job_template_id = someid
   wait_for_completion = true
 }
+
+resource "awx_workflow_job_template_launch" "deploy" {
+  workflow_job_template_id = awx_workflow_job_template.deploy.id
+  inventory_id             = awx_inventory.staging.id
+  limit                    = "webservers"
+  extra_vars               = jsonencode({ release = "1.2.3" })
+  wait_for_completion      = true
+}
+
+output "failed_nodes" {
+  value = [for node in awx_workflow_job_template_launch.deploy.nodes : node.template_name if node.failed]
+}
 ```
 
 */
@@ -32,7 +44,9 @@ func resourceWorkflowJobTeamplateLaunch() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTeamplateLaunchCreate,
 		ReadContext:   resourceWorkflowJobRead,
+		UpdateContext: resourceWorkflowJobUpdate,
 		DeleteContext: resourceWorkflowJobDelete,
+		CustomizeDiff: customizeDiffLaunch("WorkflowJobTemplate", "workflow_job_template_id", workflowJobTemplateLaunchAPIEndpoint, workflowJobTemplateLaunchPrompts, workflowJobLaunchArguments),
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
@@ -44,10 +58,35 @@ func resourceWorkflowJobTeamplateLaunch() *schema.Resource {
 			"extra_vars": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Override workflow job template variables. YAML or JSON values are supported.",
+				Description: "Override workflow job template variables and answer its survey. YAML or JSON values are supported.",
 				ForceNew:    true,
 				StateFunc:   normalizeJsonYaml,
 			},
+			"inventory_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Override Inventory ID. Required ask_inventory_on_launch set on workflow_job_template.",
+				ForceNew:    true,
+			},
+			"limit": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List of comma delimited hosts to limit job execution. Required ask_limit_on_launch set on workflow_job_template.",
+				ForceNew:    true,
+			},
+			"scm_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Override project branch. Required ask_scm_branch_on_launch set on workflow_job_template.",
+				ForceNew:    true,
+			},
+			"label_ids": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "Override label IDs. Required ask_labels_on_launch set on workflow_job_template.",
+				ForceNew:    true,
+			},
 			"wait_for_completion": {
 				Type:        schema.TypeBool,
 				Required:    false,
@@ -56,9 +95,97 @@ func resourceWorkflowJobTeamplateLaunch() *schema.Resource {
 				Description: "Resource creation will wait for workflow job completion.",
 				ForceNew:    true,
 			},
+			"cancel_on_interrupt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Cancel the workflow job when Terraform is interrupted or the create timeout expires while waiting for its completion.",
+			},
+			"cancel_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Cancel the workflow job when the resource is destroyed while it is still running.",
+			},
+			"delete_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the workflow job record from AWX when the resource is destroyed.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the workflow job: new, pending, waiting, running, successful, failed, error or canceled.",
+			},
+			"failed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the workflow job failed.",
+			},
+			"started": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the workflow job started.",
+			},
+			"finished": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the workflow job finished.",
+			},
+			"elapsed": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Elapsed time of the workflow job in seconds.",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes of the workflow job with the result of the job each one spawned.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Workflow job node ID",
+						},
+						"job_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the job spawned by the node, 0 when the node did not run.",
+						},
+						"job_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the job spawned by the node, such as job, project_update or workflow_approval.",
+						},
+						"template_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the template run by the node.",
+						},
+						"template_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the template run by the node.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the job spawned by the node.",
+						},
+						"failed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job spawned by the node failed.",
+						},
+					},
+				},
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 	}
 }
@@ -67,30 +194,102 @@ func statusInstanceWorkflowState(ctx context.Context, svc *awx.WorkflowJobServic
 	return func() (interface{}, string, error) {
 
 		output, err := svc.GetWorkflowJob(id, map[string]string{})
+		if err != nil {
+			return nil, "", err
+		}
 
-		return output, output.Status, err
+		return output, output.Status, nil
 	}
 }
 
-func workflowJobTemplateLaunchWait(ctx context.Context, svc *awx.WorkflowJobService, job *awx.JobLaunch, timeout time.Duration) error {
+func workflowJobTemplateLaunchWait(ctx context.Context, svc *awx.WorkflowJobService, id int, timeout time.Duration) (*awx.WorkflowJob, error) {
 
 	stateConf := &retry.StateChangeConf{
-		Pending:    []string{"new", "pending", "waiting", "running"},
-		Target:     []string{"successful"},
-		Refresh:    statusInstanceWorkflowState(ctx, svc, job.ID),
+		Pending:    jobPendingStatuses,
+		Target:     jobFinalStatuses,
+		Refresh:    statusInstanceWorkflowState(ctx, svc, id),
 		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*awx.WorkflowJob), nil
+}
+
+const (
+	workflowJobAPIEndpoint               = "/api/v2/workflow_jobs/%d/"
+	workflowJobTemplateLaunchAPIEndpoint = "/api/v2/workflow_job_templates/%d/launch/"
+)
 
-	return err
+// workflowJobTemplateLaunchPrompts maps the launch attributes to the workflow
+// job template setting allowing them to be overridden at launch.
+var workflowJobTemplateLaunchPrompts = map[string]string{
+	"inventory_id": "ask_inventory_on_launch",
+	"limit":        "ask_limit_on_launch",
+	"scm_branch":   "ask_scm_branch_on_launch",
+	"label_ids":    "ask_labels_on_launch",
+}
+
+// workflowJobLaunchArguments are the arguments the workflow job is launched
+// with, whose change replaces it.
+var workflowJobLaunchArguments = []string{
+	"workflow_job_template_id", "extra_vars", "inventory_id", "limit", "scm_branch", "label_ids",
+}
+
+// workflowJobNode is an entry of the workflow_nodes list of a workflow job.
+type workflowJobNode struct {
+	ID            int `json:"id"`
+	SummaryFields struct {
+		Job struct {
+			ID     int    `json:"id"`
+			Type   string `json:"type"`
+			Status string `json:"status"`
+			Failed bool   `json:"failed"`
+		} `json:"job"`
+		UnifiedJobTemplate struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"unified_job_template"`
+	} `json:"summary_fields"`
+}
+
+type workflowJobNodesResponse struct {
+	Next    interface{}       `json:"next"`
+	Results []workflowJobNode `json:"results"`
+}
+
+// listWorkflowJobNodes returns every node of a workflow job.
+func listWorkflowJobNodes(client *apiClient, id int) ([]workflowJobNode, error) {
+	var nodes []workflowJobNode
+	for page := 1; ; page++ {
+		res := new(workflowJobNodesResponse)
+		err := client.getJSON(fmt.Sprintf(workflowJobAPIEndpoint, id)+"workflow_nodes/", res, map[string]string{
+			"order_by":  "id",
+			"page":      strconv.Itoa(page),
+			"page_size": "200",
+		})
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, res.Results...)
+		if res.Next == nil {
+			return nodes, nil
+		}
+	}
 }
 
 // WorkflokJobTemplateLaunchData provides payload data used by the WorkflowJobTemplateLaunch method
 type WorkflokJobTemplateLaunchData struct {
-	ExtraVars string `json:"extra_vars,omitempty"`
+	ExtraVars   string `json:"extra_vars,omitempty"`
+	InventoryID int    `json:"inventory,omitempty"`
+	Limit       string `json:"limit,omitempty"`
+	ScmBranch   string `json:"scm_branch,omitempty"`
+	LabelIDs    []int  `json:"labels,omitempty"`
 }
 
 func resourceWorkflowJobTeamplateLaunchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -105,8 +304,23 @@ func resourceWorkflowJobTeamplateLaunchCreate(ctx context.Context, d *schema.Res
 		return buildDiagNotFoundFail("Workflow job template", workflowJobTemplateID, err)
 	}
 
+	launch := map[string]interface{}{}
+	if err := apiClientFor(m).getJSON(fmt.Sprintf(workflowJobTemplateLaunchAPIEndpoint, workflowJobTemplateID), &launch, map[string]string{}); err != nil {
+		return buildDiagNotFoundFail("Workflow job template launch requirements", workflowJobTemplateID, err)
+	}
+	if problems := checkLaunchRequirements("WorkflowJobTemplate", workflowJobTemplateID, launch, workflowJobTemplateLaunchPrompts, d); len(problems) > 0 {
+		for _, problem := range problems {
+			diags = append(diags, buildDiagnosticsMessage("WorkflowJobTemplate launch requirements not met", "%s", problem)...)
+		}
+		return diags
+	}
+
 	data := WorkflokJobTemplateLaunchData{
-		ExtraVars: d.Get("extra_vars").(string),
+		ExtraVars:   d.Get("extra_vars").(string),
+		InventoryID: d.Get("inventory_id").(int),
+		Limit:       d.Get("limit").(string),
+		ScmBranch:   d.Get("scm_branch").(string),
+		LabelIDs:    toIntSlice(d.Get("label_ids").([]interface{})),
 	}
 
 	var iData map[string]interface{}
@@ -116,44 +330,124 @@ func resourceWorkflowJobTeamplateLaunchCreate(ctx context.Context, d *schema.Res
 	res, err := awxService.Launch(workflowJobTemplateID, iData, map[string]string{})
 	if err != nil {
 		log.Printf("Failed to create Workflow Template Launch %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create workflowJobTemplate",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateLaunch with template ID %d, failed to create %s", d.Get("workflow_job_template_id").(int), err.Error()),
-		})
-		return diags
+		return append(diags, buildDiagAPIFail(
+			"Unable to create workflowJobTemplate",
+			fmt.Sprintf("WorkflowJobTemplateLaunch with template ID %d, failed to create", d.Get("workflow_job_template_id").(int)),
+			err, d,
+		)...)
 	}
 
 	// return resourceWorkflowJobRead(ctx, d, m)
 	d.SetId(strconv.Itoa(res.ID))
 	if d.Get("wait_for_completion").(bool) { // Print the full structure of the result object
-		err = workflowJobTemplateLaunchWait(ctx, awxWorkflowJobService, res, d.Timeout(schema.TimeoutCreate))
+		job, err := workflowJobTemplateLaunchWait(ctx, awxWorkflowJobService, res.ID, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
+			if isWaitInterrupted(ctx, err) && d.Get("cancel_on_interrupt").(bool) {
+				if _, err := awxWorkflowJobService.CancelWorkflowJob(res.ID, map[string]interface{}{}, map[string]string{}); err != nil {
+					log.Printf("Failed to cancel Workflow Job %d %v", res.ID, err)
+				}
+			}
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "WorkflowJobTemplate execution failure",
 				Detail:   fmt.Sprintf("WorkflowJobTemplateLaunch with ID %d and Workflow template ID %d, failed to complete %s", res.ID, d.Get("workflow_job_template_id").(int), err.Error()),
 			})
+		} else if job.Status != awx.WorkflowJobStatusSuccessful {
+			diags = append(diags, buildDiagJobFailed(
+				"WorkflowJobTemplate",
+				res.ID,
+				job.Status,
+				apiClientFor(m).uiURL("/jobs/workflow/%d/output", res.ID),
+				job.JobExplanation,
+				"",
+			)...)
 		}
 	}
-	return diags
+	return append(diags, resourceWorkflowJobRead(ctx, d, m)...)
 }
 
 func resourceWorkflowJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	client := apiClientFor(m)
+	jobID, diags := convertStateIDToNummeric("Read Workflow Job", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	job := new(jobOutcome)
+	if err := client.getJSON(fmt.Sprintf(workflowJobAPIEndpoint, jobID), job, map[string]string{}); err != nil {
+		if isNotFound(err) {
+			// AWX purges old jobs, the launch itself still happened so keep the last known state.
+			log.Printf("Workflow job %d not found, keeping its last known outcome", jobID)
+			return diags
+		}
+		return buildDiagNotFoundFail("Workflow job", jobID, err)
+	}
+
+	nodes, err := listWorkflowJobNodes(client, jobID)
+	if err != nil {
+		return buildDiagnosticsMessage("Unable to fetch workflow job nodes", "Unable to load nodes of workflow job %d: got %s", jobID, err.Error())
+	}
+
+	nodesState := make([]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		nodesState = append(nodesState, map[string]interface{}{
+			"id":            node.ID,
+			"job_id":        node.SummaryFields.Job.ID,
+			"job_type":      node.SummaryFields.Job.Type,
+			"template_id":   node.SummaryFields.UnifiedJobTemplate.ID,
+			"template_name": node.SummaryFields.UnifiedJobTemplate.Name,
+			"status":        node.SummaryFields.Job.Status,
+			"failed":        node.SummaryFields.Job.Failed,
+		})
+	}
+
+	d.Set("status", job.Status)
+	d.Set("failed", job.Failed)
+	d.Set("started", job.Started)
+	d.Set("finished", job.Finished)
+	d.Set("elapsed", job.Elapsed)
+	d.Set("nodes", nodesState)
 	return diags
 }
 
+// resourceWorkflowJobUpdate only stores the new lifecycle settings, every other attribute forces a new launch.
+func resourceWorkflowJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceWorkflowJobRead(ctx, d, m)
+}
+
 func resourceWorkflowJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobService
 	jobID, diags := convertStateIDToNummeric("Delete Workflow Job", d)
-	_, err := awxService.GetWorkflowJob(jobID, map[string]string{})
+	if diags.HasError() {
+		return diags
+	}
+	job, err := awxService.GetWorkflowJob(jobID, map[string]string{})
+	if isNotFound(err) {
+		d.SetId("")
+		return diags
+	}
 	if err != nil {
 		return buildDiagNotFoundFail("Workflow job", jobID, err)
 	}
 
+	if d.Get("cancel_on_destroy").(bool) && isJobPending(job.Status) {
+		if _, err := awxService.CancelWorkflowJob(jobID, map[string]interface{}{}, map[string]string{}); err != nil {
+			return buildDiagDeleteFail("Workflow Job", fmt.Sprintf("unable to cancel WorkflowJobID %v, got %s ", jobID, err.Error()))
+		}
+		if _, err := workflowJobTemplateLaunchWait(ctx, awxService, jobID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return buildDiagDeleteFail("Workflow Job", fmt.Sprintf("WorkflowJobID %v not canceled, got %s ", jobID, err.Error()))
+		}
+	}
+
+	if d.Get("delete_on_destroy").(bool) {
+		if err := apiClientFor(m).delete(fmt.Sprintf(workflowJobAPIEndpoint, jobID)); err != nil && !isNotFound(err) {
+			return buildDiagDeleteFail("Workflow Job", fmt.Sprintf("WorkflowJobID %v, got %s ", jobID, err.Error()))
+		}
+	}
+
 	d.SetId("")
 	return diags
 }
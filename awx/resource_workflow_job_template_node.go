@@ -120,10 +120,8 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 				Required: true,
 			},
 		},
-		//Importer: &schema.ResourceImporter{
-		//	State: schema.ImportStatePassthrough,
-		//},
-		//
+		Importer: importStateNaturalKey(workflowNodeKey),
+
 		//Timeouts: &schema.ResourceTimeout{
 		//	Create: schema.DefaultTimeout(1 * time.Minute),
 		//	Update: schema.DefaultTimeout(1 * time.Minute),
@@ -133,7 +131,6 @@ func resourceWorkflowJobTemplateNode() *schema.Resource {
 }
 
 func resourceWorkflowJobTemplateNodeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateNodeService
 
@@ -158,12 +155,7 @@ func resourceWorkflowJobTemplateNodeCreate(ctx context.Context, d *schema.Resour
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Template %v", err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create WorkflowJobTemplateNode",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with JobTemplateID %d and WorkflowID: %d failed to create %s", d.Get("unified_job_template_id").(int), d.Get("workflow_job_template_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create WorkflowJobTemplateNode", fmt.Sprintf("WorkflowJobTemplateNode with JobTemplateID %d and WorkflowID: %d failed to create", d.Get("unified_job_template_id").(int), d.Get("workflow_job_template_id").(int)), err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
@@ -182,7 +174,7 @@ func resourceWorkflowJobTemplateNodeUpdate(ctx context.Context, d *schema.Resour
 	params := make(map[string]string)
 	_, err := awxService.GetWorkflowJobTemplateNodeByID(id, params)
 	if err != nil {
-		return buildDiagNotFoundFail("workflow job template node", id, err)
+		return buildDiagFetchFail("workflow job template node", id, err)
 	}
 
 	_, err = awxService.UpdateWorkflowJobTemplateNode(id, map[string]interface{}{
@@ -204,12 +196,7 @@ func resourceWorkflowJobTemplateNodeUpdate(ctx context.Context, d *schema.Resour
 		"identifier":                d.Get("identifier").(string),
 	}, map[string]string{})
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to update WorkflowJobTemplateNode",
-			Detail:   fmt.Sprintf("WorkflowJobTemplateNode with name %s in the project id %d failed to update %s", d.Get("name").(string), d.Get("project_id").(int), err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to update WorkflowJobTemplateNode", fmt.Sprintf("WorkflowJobTemplateNode with name %s in the project id %d failed to update", d.Get("name").(string), d.Get("project_id").(int)), err, d)
 	}
 
 	return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
@@ -226,7 +213,7 @@ func resourceWorkflowJobTemplateNodeRead(ctx context.Context, d *schema.Resource
 
 	res, err := awxService.GetWorkflowJobTemplateNodeByID(id, make(map[string]string))
 	if err != nil {
-		return buildDiagNotFoundFail("workflow job template node", id, err)
+		return buildDiagReadFail("workflow job template node", id, err, d)
 
 	}
 	d = setWorkflowJobTemplateNodeResourceData(d, res)
@@ -241,7 +228,7 @@ func resourceWorkflowJobTemplateNodeDelete(ctx context.Context, d *schema.Resour
 		return diags
 	}
 
-	if _, err := awxService.DeleteWorkflowJobTemplateNode(id); err != nil {
+	if _, err := awxService.DeleteWorkflowJobTemplateNode(id); err != nil && !isNotFound(err) {
 		return buildDiagDeleteFail(
 			diagElementHostTitle,
 			fmt.Sprintf("id %v, got %s ",
@@ -33,6 +33,7 @@ func resourceWorkflowJobTemplateNodeAlways() *schema.Resource {
 		UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
 		DeleteContext: resourceWorkflowJobTemplateNodeDelete,
 		Schema:        workflowJobNodeSchema,
+		Importer:      importStateCompositeID("workflow_job_template_node_id", workflowNodeKey, "", workflowNodeKey),
 	}
 }
 func resourceWorkflowJobTemplateNodeAlwaysCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -33,6 +33,7 @@ func resourceWorkflowJobTemplateNodeFailure() *schema.Resource {
         UpdateContext: resourceWorkflowJobTemplateNodeUpdate,
         DeleteContext: resourceWorkflowJobTemplateNodeDelete,
         Schema:        workflowJobNodeSchema,
+        Importer:      importStateCompositeID("workflow_job_template_node_id", workflowNodeKey, "", workflowNodeKey),
     }
 }
 
@@ -81,7 +81,6 @@ var workflowJobNodeSchema = map[string]*schema.Schema{
 }
 
 func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService, ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-    var diags diag.Diagnostics
     templateNodeID := d.Get("workflow_job_template_node_id").(int)
     result, err := awxService.CreateWorkflowJobTemplateNodeStep(templateNodeID, map[string]interface{}{
         "extra_data":            d.Get("extra_data").(string),
@@ -104,12 +103,7 @@ func createNodeForWorkflowJob(awxService *awx.WorkflowJobTemplateNodeStepService
     }, map[string]string{})
     if err != nil {
         log.Printf("Fail to Create Template %v", err)
-        diags = append(diags, diag.Diagnostic{
-            Severity: diag.Error,
-            Summary:  "Unable to create WorkflowJobTemplateNodeSuccess",
-            Detail:   fmt.Sprintf("WorkflowJobTemplateNodeSuccess with JobTemplateID %d failed to create %s", d.Get("unified_job_template_id").(int), err.Error()),
-        })
-        return diags
+        return buildDiagAPIFail("Unable to create WorkflowJobTemplateNodeSuccess", fmt.Sprintf("WorkflowJobTemplateNodeSuccess with JobTemplateID %d failed to create", d.Get("unified_job_template_id").(int)), err, d)
     }
     d.SetId(strconv.Itoa(result.ID))
     return resourceWorkflowJobTemplateNodeRead(ctx, d, m)
@@ -21,7 +21,7 @@ func resourceWorkflowJobTemplateNotificationTemplateError() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNotificationTemplateCreateForType("error"),
 		DeleteContext: resourceWorkflowJobTemplateNotificationTemplateDeleteForType("error"),
-		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateReadForType("error"),
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
@@ -35,5 +35,6 @@ func resourceWorkflowJobTemplateNotificationTemplateError() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("workflow_job_template_id", workflowJobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
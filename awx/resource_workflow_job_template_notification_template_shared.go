@@ -2,6 +2,7 @@ package awx
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	awx "github.com/denouche/goawx/client"
@@ -61,9 +62,12 @@ func resourceWorkflowJobTemplateNotificationTemplateCreateForType(typ string) fu
 	}
 }
 
-func resourceWorkflowJobTemplateNotificationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	return diags
+func resourceWorkflowJobTemplateNotificationTemplateReadForType(typ string) schema.ReadContextFunc {
+	return readAssociation(
+		fmt.Sprintf("WorkflowJobTemplate notification_template %s", typ),
+		"/api/v2/workflow_job_templates/%d/notification_templates_"+typ+"/",
+		"workflow_job_template_id", "notification_template_id",
+	)
 }
 
 func resourceWorkflowJobTemplateNotificationTemplateDeleteForType(typ string) func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -73,6 +77,10 @@ func resourceWorkflowJobTemplateNotificationTemplateDeleteForType(typ string) fu
 		awxWorkflowJobTemplateService := client.WorkflowJobTemplateService
 		workflowJobTemplateID := d.Get("workflow_job_template_id").(int)
 		_, err := awxWorkflowJobTemplateService.GetWorkflowJobTemplateByID(workflowJobTemplateID, make(map[string]string))
+		if isNotFound(err) {
+			d.SetId("")
+			return diags
+		}
 		if err != nil {
 			return buildDiagNotFoundFail("workflow job template", workflowJobTemplateID, err)
 		}
@@ -85,7 +93,7 @@ func resourceWorkflowJobTemplateNotificationTemplateDeleteForType(typ string) fu
 		}
 
 		_, err = disassociationFunc(workflowJobTemplateID, notificationTemplateID)
-		if err != nil {
+		if err != nil && !isNotFound(err) {
 			return buildDiagnosticsMessage("Create: WorkflowJobTemplate not DisassociateWorkflowJobTemplateNotificationTemplates", "Fail to associate notification_template credentials with ID %v, for job_template ID %v, got error: %s", notificationTemplateID, workflowJobTemplateID, err.Error())
 		}
 
@@ -21,7 +21,7 @@ func resourceWorkflowJobTemplateNotificationTemplateStarted() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNotificationTemplateCreateForType("started"),
 		DeleteContext: resourceWorkflowJobTemplateNotificationTemplateDeleteForType("started"),
-		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateReadForType("started"),
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
@@ -35,5 +35,6 @@ func resourceWorkflowJobTemplateNotificationTemplateStarted() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("workflow_job_template_id", workflowJobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
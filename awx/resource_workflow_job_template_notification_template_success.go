@@ -21,7 +21,7 @@ func resourceWorkflowJobTemplateNotificationTemplateSuccess() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateNotificationTemplateCreateForType("success"),
 		DeleteContext: resourceWorkflowJobTemplateNotificationTemplateDeleteForType("success"),
-		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateRead,
+		ReadContext:   resourceWorkflowJobTemplateNotificationTemplateReadForType("success"),
 
 		Schema: map[string]*schema.Schema{
 			"workflow_job_template_id": {
@@ -35,5 +35,6 @@ func resourceWorkflowJobTemplateNotificationTemplateSuccess() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		Importer: importStateCompositeID("workflow_job_template_id", workflowJobTemplateKey, "notification_template_id", notificationTemplateKey),
 	}
 }
@@ -20,7 +20,6 @@ package awx
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"strconv"
 
@@ -32,7 +31,7 @@ import (
 func resourceWorkflowJobTemplateSchedule() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkflowJobTemplateScheduleCreate,
-		ReadContext:   resourceScheduleRead,
+		ReadContext:   resourceWorkflowJobTemplateScheduleRead,
 		UpdateContext: resourceScheduleUpdate,
 		DeleteContext: resourceScheduleDelete,
 		Schema: map[string]*schema.Schema{
@@ -75,11 +74,11 @@ func resourceWorkflowJobTemplateSchedule() *schema.Resource {
 				Description: "Extra data to be pass for the schedule (YAML format)",
 			},
 		},
+		Importer: importStateNaturalKey(scheduleKey),
 	}
 }
 
 func resourceWorkflowJobTemplateScheduleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
 	client := m.(*awx.AWX)
 	awxService := client.WorkflowJobTemplateScheduleService
 
@@ -95,14 +94,26 @@ func resourceWorkflowJobTemplateScheduleCreate(ctx context.Context, d *schema.Re
 	}, map[string]string{})
 	if err != nil {
 		log.Printf("Fail to Create Schedule for WorkflowJobTemplate %d: %v", workflowJobTemplateID, err)
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Unable to create Schedule",
-			Detail:   fmt.Sprintf("Schedule failed to create %s", err.Error()),
-		})
-		return diags
+		return buildDiagAPIFail("Unable to create Schedule", "Schedule failed to create", err, d)
 	}
 
 	d.SetId(strconv.Itoa(result.ID))
-	return resourceScheduleRead(ctx, d, m)
+	return resourceWorkflowJobTemplateScheduleRead(ctx, d, m)
+}
+
+func resourceWorkflowJobTemplateScheduleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*awx.AWX)
+	awxService := client.ScheduleService
+	id, diags := convertStateIDToNummeric("Read schedule", d)
+	if diags.HasError() {
+		return diags
+	}
+
+	res, err := awxService.GetByID(id, make(map[string]string))
+	if err != nil {
+		return buildDiagReadFail("schedule", id, err, d)
+	}
+	setScheduleResourceData(d, res)
+	d.Set("workflow_job_template_id", res.UnifiedJobTemplate)
+	return nil
 }
@@ -0,0 +1,148 @@
+package awx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// secretSource reads a credential from a command or a file, and keeps it
+// until AWX rejects it.
+type secretSource struct {
+	name  string
+	fetch func(ctx context.Context) (string, error)
+	// logCtx holds the provider logger, captured at construction since the
+	// requests renewing the secret carry none.
+	logCtx context.Context
+
+	mu     sync.Mutex
+	secret string
+}
+
+// newCommandSecretSource returns the secret printed on stdout by command,
+// which runs in the shell of the platform.
+func newCommandSecretSource(ctx context.Context, name, command string) *secretSource {
+	return &secretSource{
+		name:   name,
+		logCtx: ctx,
+		fetch: func(ctx context.Context) (string, error) {
+			shell, flag := "sh", "-c"
+			if runtime.GOOS == "windows" {
+				shell, flag = "cmd", "/C"
+			}
+			var stdout, stderr bytes.Buffer
+			cmd := exec.CommandContext(ctx, shell, flag, command)
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+			if err := cmd.Run(); err != nil {
+				return "", fmt.Errorf("%s failed: %s: %s", name, err, strings.TrimSpace(stderr.String()))
+			}
+			return strings.TrimSpace(stdout.String()), nil
+		},
+	}
+}
+
+// newFileSecretSource returns the content of path, read again on renewal so
+// that a broker can rotate the file.
+func newFileSecretSource(ctx context.Context, name, path string) *secretSource {
+	return &secretSource{
+		name:   name,
+		logCtx: ctx,
+		fetch: func(ctx context.Context) (string, error) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("unable to read %s: %s", name, err)
+			}
+			return strings.TrimSpace(string(content)), nil
+		},
+	}
+}
+
+// get returns the current secret, fetching it the first time.
+func (s *secretSource) get(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret != "" {
+		return s.secret, nil
+	}
+	return s.load(ctx)
+}
+
+// renew fetches the secret again unless it changed since rejected was read,
+// so that concurrent requests rejected with the same secret renew it once.
+func (s *secretSource) renew(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret != rejected {
+		return s.secret, nil
+	}
+	tflog.Info(s.logCtx, "AWX rejected the credentials, reading them again", map[string]interface{}{
+		"source": s.name,
+	})
+	return s.load(ctx)
+}
+
+func (s *secretSource) load(ctx context.Context) (string, error) {
+	secret, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", fmt.Errorf("%s returned an empty value", s.name)
+	}
+	s.secret = secret
+	return secret, nil
+}
+
+// secretAuthTransport sets the credentials of a secretSource on every request,
+// in place of the ones goawx was built with. When AWX answers 401 the secret
+// is read again and the request replayed, so that the short-lived tokens of
+// a broker keep working during long applies.
+type secretAuthTransport struct {
+	next      http.RoundTripper
+	source    *secretSource
+	authorize func(req *http.Request, secret string)
+}
+
+func (t *secretAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	secret, err := t.source.get(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(t.authenticate(req, secret))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	renewed, err := t.source.renew(req.Context(), secret)
+	if err != nil || renewed == secret {
+		return resp, nil
+	}
+	retry := t.authenticate(req, renewed)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	return t.next.RoundTrip(retry)
+}
+
+func (t *secretAuthTransport) authenticate(req *http.Request, secret string) *http.Request {
+	req = req.Clone(req.Context())
+	t.authorize(req, secret)
+	return req
+}
@@ -0,0 +1,167 @@
+package awx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	pingAPIEndpoint   = "/api/v2/ping/"
+	configAPIEndpoint = "/api/v2/config/"
+)
+
+// serverInfo describes the AWX server a provider instance talks to, as
+// detected once when the provider is configured.
+type serverInfo struct {
+	Version     string
+	LicenseType string
+	InstallUUID string
+}
+
+// loadServerInfo reads the version and install UUID from the ping endpoint,
+// and the license type from the config endpoint.
+func (c *apiClient) loadServerInfo() error {
+	var ping struct {
+		Version     string `json:"version"`
+		InstallUUID string `json:"install_uuid"`
+	}
+	if err := c.getJSON(pingAPIEndpoint, &ping, map[string]string{}); err != nil {
+		return err
+	}
+	var config struct {
+		LicenseInfo struct {
+			LicenseType string `json:"license_type"`
+		} `json:"license_info"`
+	}
+	if err := c.getJSON(configAPIEndpoint, &config, map[string]string{}); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.server = serverInfo{
+		Version:     ping.Version,
+		LicenseType: config.LicenseInfo.LicenseType,
+		InstallUUID: ping.InstallUUID,
+	}
+	return nil
+}
+
+// detectedServer returns the server info, empty until loadServerInfo
+// succeeded.
+func (c *apiClient) detectedServer() serverInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.server
+}
+
+// awxFeature is an API capability only recent servers have. Automation
+// Controller, the AAP build of AWX, has its own version numbers, hence the
+// minimum version of both.
+type awxFeature struct {
+	name       string
+	awx        string
+	controller string
+}
+
+var (
+	featureExecutionEnvironments = awxFeature{
+		name:       "Execution environments",
+		awx:        "18.0.0",
+		controller: "4.0.0",
+	}
+	featureLaunchPrompts = awxFeature{
+		name:       "Prompting for labels, forks, timeout, job slicing, instance groups and execution environment on launch",
+		awx:        "21.10.0",
+		controller: "4.3.0",
+	}
+	featurePreventInstanceGroupFallback = awxFeature{
+		name:       "Preventing the instance group fallback",
+		awx:        "21.10.0",
+		controller: "4.3.0",
+	}
+	featureConstructedInventories = awxFeature{
+		name:       "Constructed inventories",
+		awx:        "22.0.0",
+		controller: "4.4.0",
+	}
+)
+
+// require returns an error when the server is older than the minimum version
+// of feature. Nothing is enforced when the server could not be identified.
+func (s serverInfo) require(feature awxFeature) error {
+	current, ok := parseVersion(s.Version)
+	if !ok || s.LicenseType == "" {
+		return nil
+	}
+	product, minimum := "AWX", feature.awx
+	if s.LicenseType != "open" {
+		product, minimum = "Automation Controller", feature.controller
+	}
+	required, _ := parseVersion(minimum)
+	if compareVersions(current, required) < 0 {
+		return fmt.Errorf("%s requires %s >= %s, the server runs %s %s", feature.name, product, minimum, product, s.Version)
+	}
+	return nil
+}
+
+func requireFeature(m interface{}, feature awxFeature) error {
+	api := apiClientFor(m)
+	if api == nil {
+		return nil
+	}
+	return api.detectedServer().require(feature)
+}
+
+// customizeDiffRequires fails the plan when one of keys is set while the
+// server does not support feature.
+func customizeDiffRequires(feature awxFeature, keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		for _, key := range keys {
+			if _, ok := d.GetOk(key); !ok {
+				continue
+			}
+			if err := requireFeature(m, feature); err != nil {
+				return fmt.Errorf("%s: %s", key, err)
+			}
+		}
+		return nil
+	}
+}
+
+// parseVersion parses the leading numeric components of an AWX version, such
+// as 23.0.1 for 23.0.1.dev35+g2a5a2f5.
+func parseVersion(version string) ([]int, bool) {
+	var parsed []int
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parsed = append(parsed, n)
+	}
+	return parsed, len(parsed) > 0
+}
+
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}